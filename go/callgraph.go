@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/theapemachine/platform-graph/graphstore"
+)
+
+// analyzeCallGraph loads projectRoot with go/packages, resolves every call
+// expression and import to its target using the type checker, and writes
+// CALLS, IMPORTS, and IMPLEMENTS edges to store. It runs as a second pass
+// after processGoFile/processGenDecl/processFuncDecl have created the
+// Package/Struct/Function/Method/Interface nodes these edges connect.
+func analyzeCallGraph(ctx context.Context, store graphstore.GraphStore, projectRoot, rootName string) error {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports,
+		Dir: projectRoot,
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	funcIDs := map[types.Object]string{}
+	interfaces := map[string]*types.Interface{} // interfaceId -> underlying type
+	structs := map[string]*types.Named{}        // structId -> named type
+
+	// First pass: assign the same IDs processFuncDecl/processGenDecl gave
+	// each declaration, so a callee in a different package resolves to the
+	// node the AST pass already created.
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			packageID := packageIDFor(pkg, file, projectRoot, rootName)
+
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if obj := pkg.TypesInfo.Defs[d.Name]; obj != nil {
+						funcIDs[obj] = funcIDFor(d, packageID)
+					}
+				case *ast.GenDecl:
+					collectTypeDecl(pkg, d, packageID, interfaces, structs)
+				}
+			}
+		}
+	}
+
+	// Second pass: walk call expressions and import lists, writing edges
+	// between the IDs collected above.
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			packageID := packageIDFor(pkg, file, projectRoot, rootName)
+			linkFileImports(ctx, store, pkg, file, packageID, projectRoot, rootName)
+
+			for _, decl := range file.Decls {
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Body == nil {
+					continue
+				}
+				callerID, ok := funcIDs[pkg.TypesInfo.Defs[funcDecl.Name]]
+				if !ok {
+					continue
+				}
+				linkCalls(ctx, store, pkg, funcDecl, callerID, funcIDs)
+			}
+		}
+	}
+
+	linkImplements(ctx, store, structs, interfaces)
+
+	return nil
+}
+
+func packageIDFor(pkg *packages.Package, file *ast.File, projectRoot, rootName string) string {
+	filePath := pkg.Fset.Position(file.Pos()).Filename
+	relativePath := strings.TrimPrefix(filePath, projectRoot+"/")
+	return fmt.Sprintf("%s:%s", rootName, filepath.Dir(relativePath))
+}
+
+func funcIDFor(fn *ast.FuncDecl, packageID string) string {
+	if fn.Recv != nil && len(fn.Recv.List) > 0 {
+		if recvType := extractReceiverType(fn.Recv); recvType != "" {
+			return fmt.Sprintf("%s.%s.%s", packageID, recvType, fn.Name.Name)
+		}
+	}
+	return fmt.Sprintf("%s.%s", packageID, fn.Name.Name)
+}
+
+func collectTypeDecl(pkg *packages.Package, decl *ast.GenDecl, packageID string, interfaces map[string]*types.Interface, structs map[string]*types.Named) {
+	for _, spec := range decl.Specs {
+		typeSpec, ok := spec.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+		obj, ok := pkg.TypesInfo.Defs[typeSpec.Name].(*types.TypeName)
+		if !ok {
+			continue
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		id := fmt.Sprintf("%s.%s", packageID, typeSpec.Name.Name)
+		switch underlying := named.Underlying().(type) {
+		case *types.Interface:
+			interfaces[id] = underlying
+		case *types.Struct:
+			structs[id] = named
+		}
+	}
+}
+
+func linkFileImports(ctx context.Context, store graphstore.GraphStore, pkg *packages.Package, file *ast.File, packageID, projectRoot, rootName string) {
+	for _, imp := range file.Imports {
+		importedPkg := pkg.Imports[strings.Trim(imp.Path.Value, `"`)]
+		if importedPkg == nil || len(importedPkg.Syntax) == 0 {
+			continue // not a package that belongs to this project
+		}
+		importedID := packageIDFor(importedPkg, importedPkg.Syntax[0], projectRoot, rootName)
+		if err := store.LinkImports(ctx, packageID, importedID); err != nil {
+			log.Printf("Failed to link %s imports %s: %v", packageID, importedID, err)
+		}
+	}
+}
+
+func linkCalls(ctx context.Context, store graphstore.GraphStore, pkg *packages.Package, fn *ast.FuncDecl, callerID string, funcIDs map[types.Object]string) {
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		ident := calleeIdent(call.Fun)
+		if ident == nil {
+			return true
+		}
+		calleeID, ok := funcIDs[pkg.TypesInfo.Uses[ident]]
+		if !ok {
+			return true
+		}
+		if err := store.LinkCalls(ctx, callerID, calleeID); err != nil {
+			log.Printf("Failed to link %s calls %s: %v", callerID, calleeID, err)
+		}
+		return true
+	})
+}
+
+// calleeIdent extracts the identifier naming the function or method being
+// called, e.g. `foo` in `foo()` or `Sel` in `pkg.Sel()`/`recv.Sel()`.
+func calleeIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		return e.Sel
+	}
+	return nil
+}
+
+func linkImplements(ctx context.Context, store graphstore.GraphStore, structs map[string]*types.Named, interfaces map[string]*types.Interface) {
+	for structID, named := range structs {
+		for interfaceID, iface := range interfaces {
+			if types.Implements(named, iface) || types.Implements(types.NewPointer(named), iface) {
+				if err := store.LinkImplements(ctx, structID, interfaceID); err != nil {
+					log.Printf("Failed to link %s implements %s: %v", structID, interfaceID, err)
+				}
+			}
+		}
+	}
+}
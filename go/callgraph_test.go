@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/theapemachine/platform-graph/graphstore"
+)
+
+// recordingStore is a graphstore.GraphStore that only records the CALLS and
+// IMPLEMENTS edges analyzeCallGraph's helpers write, for asserting against in
+// tests.
+type recordingStore struct {
+	implements [][2]string
+	calls      [][2]string
+}
+
+func (r *recordingStore) UpsertPackage(context.Context, graphstore.Package) error     { return nil }
+func (r *recordingStore) UpsertStruct(context.Context, graphstore.Struct) error       { return nil }
+func (r *recordingStore) UpsertFunction(context.Context, graphstore.Function) error   { return nil }
+func (r *recordingStore) UpsertMethod(context.Context, graphstore.Method) error       { return nil }
+func (r *recordingStore) UpsertInterface(context.Context, graphstore.Interface) error { return nil }
+func (r *recordingStore) UpsertVariable(context.Context, graphstore.Variable) error   { return nil }
+func (r *recordingStore) UpsertConst(context.Context, graphstore.Const) error         { return nil }
+func (r *recordingStore) UpsertFile(context.Context, graphstore.File) error           { return nil }
+func (r *recordingStore) FileHash(context.Context, string) (string, bool, error) {
+	return "", false, nil
+}
+func (r *recordingStore) SymbolsInFile(context.Context, string) ([]string, error)  { return nil, nil }
+func (r *recordingStore) FilesInProject(context.Context, string) ([]string, error) { return nil, nil }
+func (r *recordingStore) DeleteSymbol(context.Context, string) error               { return nil }
+func (r *recordingStore) LinkContains(context.Context, string, string) error       { return nil }
+func (r *recordingStore) LinkImports(context.Context, string, string) error        { return nil }
+
+func (r *recordingStore) LinkCalls(_ context.Context, callerID, calleeID string) error {
+	r.calls = append(r.calls, [2]string{callerID, calleeID})
+	return nil
+}
+
+func (r *recordingStore) LinkImplements(_ context.Context, structID, interfaceID string) error {
+	r.implements = append(r.implements, [2]string{structID, interfaceID})
+	return nil
+}
+
+func (r *recordingStore) CallersOf(context.Context, string) ([]string, error) { return nil, nil }
+func (r *recordingStore) TransitiveCallees(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+func (r *recordingStore) Cleanup(context.Context, string) error { return nil }
+func (r *recordingStore) Close(context.Context) error           { return nil }
+
+var _ graphstore.GraphStore = (*recordingStore)(nil)
+
+func TestFuncIDFor(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "demo.go", `package demo
+
+func Standalone() {}
+
+func (g Greeter) Greet() {}
+
+func (g *Greeter) GreetPointer() {}
+`, 0)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Standalone", "pkg.Standalone"},
+		{"Greet", "pkg.Greeter.Greet"},
+		{"GreetPointer", "pkg.Greeter.GreetPointer"},
+	}
+
+	for _, tt := range tests {
+		var decl *ast.FuncDecl
+		for _, d := range file.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == tt.name {
+				decl = fn
+			}
+		}
+		if decl == nil {
+			t.Fatalf("no FuncDecl named %s", tt.name)
+		}
+		if got := funcIDFor(decl, "pkg"); got != tt.want {
+			t.Errorf("funcIDFor(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCalleeIdent(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "demo.go", `package demo
+
+func f() {
+	bare()
+	pkg.Selector()
+}
+`, 0)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+
+	var calls []*ast.CallExpr
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			calls = append(calls, call)
+		}
+		return true
+	})
+
+	if len(calls) != 2 {
+		t.Fatalf("found %d calls, want 2", len(calls))
+	}
+
+	if got := calleeIdent(calls[0].Fun); got == nil || got.Name != "bare" {
+		t.Errorf("calleeIdent(bare()) = %v, want ident \"bare\"", got)
+	}
+	if got := calleeIdent(calls[1].Fun); got == nil || got.Name != "Selector" {
+		t.Errorf("calleeIdent(pkg.Selector()) = %v, want ident \"Selector\"", got)
+	}
+}
+
+func TestLinkImplements(t *testing.T) {
+	pkg := types.NewPackage("demo", "demo")
+
+	sig := types.NewSignatureType(nil, nil, nil, nil, nil, false)
+	method := types.NewFunc(0, pkg, "Greet", sig)
+	iface := types.NewInterfaceType([]*types.Func{method}, nil)
+	iface.Complete()
+
+	structType := types.NewStruct(nil, nil)
+	structName := types.NewTypeName(0, pkg, "Greeter", nil)
+	named := types.NewNamed(structName, structType, nil)
+	named.AddMethod(method)
+
+	store := &recordingStore{}
+	linkImplements(context.Background(), store,
+		map[string]*types.Named{"demo.Greeter": named},
+		map[string]*types.Interface{"demo.Greet": iface})
+
+	if len(store.implements) != 1 {
+		t.Fatalf("got %d IMPLEMENTS edges, want 1: %v", len(store.implements), store.implements)
+	}
+	if store.implements[0] != [2]string{"demo.Greeter", "demo.Greet"} {
+		t.Errorf("IMPLEMENTS edge = %v, want [demo.Greeter demo.Greet]", store.implements[0])
+	}
+}
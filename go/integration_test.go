@@ -0,0 +1,142 @@
+//go:build integration
+
+package main
+
+import (
+	"context"
+	"go/token"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/testcontainers/testcontainers-go"
+	tcneo4j "github.com/testcontainers/testcontainers-go/modules/neo4j"
+
+	"github.com/theapemachine/platform-graph/graphstore"
+)
+
+// neo4jImage is the testcontainers image used for the integration suite.
+// Override it with NEO4J_TEST_IMAGE to pin a different version locally,
+// e.g. when reproducing an issue reported against an older Neo4j release.
+func neo4jImage() string {
+	if image := os.Getenv("NEO4J_TEST_IMAGE"); image != "" {
+		return image
+	}
+	return "neo4j:5.21"
+}
+
+// TestPipeline_Fixture runs the same findGoFiles + processGoFile +
+// analyzeCallGraph pipeline main() runs, against testdata/fixture, and
+// asserts the Cypher a contributor would write to validate it. It exists
+// because unit tests on the AST alone can't catch a regression in how
+// processGoFile/processFuncDecl actually land in the graph.
+func TestPipeline_Fixture(t *testing.T) {
+	ctx := context.Background()
+
+	container, err := tcneo4j.RunContainer(ctx,
+		testcontainers.WithImage(neo4jImage()),
+		tcneo4j.WithAdminPassword("platform-graph-test"),
+	)
+	if err != nil {
+		t.Fatalf("failed to start neo4j container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate neo4j container: %v", err)
+		}
+	})
+
+	uri, err := container.BoltUrl(ctx)
+	if err != nil {
+		t.Fatalf("failed to read bolt URL: %v", err)
+	}
+
+	store, err := graphstore.NewNeo4jStoreWithBatch(ctx, uri, "neo4j", "platform-graph-test", 1, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to connect to neo4j: %v", err)
+	}
+
+	const rootName = "fixture"
+	const baseURL = "http://localhost"
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	projectRoot += "/testdata/fixture"
+
+	goFiles, err := findGoFiles(projectRoot)
+	if err != nil {
+		t.Fatalf("findGoFiles: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var wg sync.WaitGroup
+	for _, filePath := range goFiles {
+		wg.Add(1)
+		go func(filePath string) {
+			defer wg.Done()
+			processGoFile(ctx, filePath, fset, projectRoot, baseURL, store, rootName, true)
+		}(filePath)
+	}
+	wg.Wait()
+
+	if err := analyzeCallGraph(ctx, store, projectRoot, rootName); err != nil {
+		t.Fatalf("analyzeCallGraph: %v", err)
+	}
+
+	if err := store.Close(ctx); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth("neo4j", "platform-graph-test", ""))
+	if err != nil {
+		t.Fatalf("failed to open assertion driver: %v", err)
+	}
+	defer driver.Close(ctx)
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	assertCount(ctx, t, session, "MATCH (p:Package {project: $project}) RETURN count(p) AS n", rootName, 2)
+	assertCount(ctx, t, session, "MATCH (s:Struct {project: $project}) RETURN count(s) AS n", rootName, 1)
+	assertCount(ctx, t, session, "MATCH (fn:Function {project: $project}) RETURN count(fn) AS n", rootName, 1)
+	assertCount(ctx, t, session, "MATCH (m:Method {project: $project}) RETURN count(m) AS n", rootName, 1)
+
+	assertCount(ctx, t, session,
+		`MATCH (s:Struct {name: "Foo", project: $project})-[:CONTAINS]->(m:Method {name: "Bar"})
+		 RETURN count(m) AS n`,
+		rootName, 1)
+
+	assertCount(ctx, t, session,
+		`MATCH (m:Method {name: "Bar", project: $project})-[:CALLS]->(fn:Function {name: "Baz"})
+		 RETURN count(fn) AS n`,
+		rootName, 1)
+
+	assertCount(ctx, t, session,
+		`MATCH (pa:Package {project: $project})-[:IMPORTS]->(pb:Package {project: $project})
+		 WHERE pa.name = "a" AND pb.name = "b"
+		 RETURN count(pb) AS n`,
+		rootName, 1)
+}
+
+// assertCount runs query (which must return a single row bound to "n") with
+// project bound as $project, and fails the test if the result doesn't equal
+// want.
+func assertCount(ctx context.Context, t *testing.T, session neo4j.SessionWithContext, query, project string, want int64) {
+	t.Helper()
+
+	result, err := session.Run(ctx, query, map[string]any{"project": project})
+	if err != nil {
+		t.Fatalf("query failed: %v\n%s", err, query)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		t.Fatalf("expected exactly one row: %v\n%s", err, query)
+	}
+	n, _ := record.Get("n")
+	got, _ := n.(int64)
+	if got != want {
+		t.Errorf("got %d, want %d\n%s", got, want, query)
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"flag"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -11,88 +13,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
-	"time"
 
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/theapemachine/platform-graph/graphstore"
 )
 
-// Node color mappings
-var NodeColors = map[string]string{
-	"Package":         "#4287f5",
-	"Function":        "#42f54e",
-	"Method":          "#42f54e",
-	"Struct":          "#f54242",
-	"Interface":       "#f5a442",
-	"ExternalService": "#f5f542",
-}
-
-// Create Neo4j driver with retry logic using context and timeout
-func createNeo4jDriver() (neo4j.DriverWithContext, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*10)
-	defer cancel()
-
-	var driver neo4j.DriverWithContext
-	var err error
-	retryDelay := time.Second * 5
-
-	neo4jUri := os.Getenv("NEO4J_URI")
-	neo4jUser := os.Getenv("NEO4J_USER")
-	neo4jPassword := os.Getenv("NEO4J_PASSWORD")
-
-	if neo4jUri == "" || neo4jUser == "" || neo4jPassword == "" {
-		return nil, fmt.Errorf("missing required environment variables: NEO4J_URI, NEO4J_USER, NEO4J_PASSWORD")
-	}
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("failed to connect to Neo4j after retries: %v", ctx.Err())
-		case <-time.After(retryDelay):
-			driver, err = neo4j.NewDriverWithContext(
-				neo4jUri,
-				neo4j.BasicAuth(neo4jUser, neo4jPassword, ""),
-			)
-			if err == nil {
-				session := driver.NewSession(ctx, neo4j.SessionConfig{})
-				defer session.Close(ctx)
-				_, err = session.Run(ctx, "RETURN 1", nil)
-				if err == nil {
-					fmt.Println("Connected to Neo4j")
-					return driver, nil
-				}
-			}
-			fmt.Printf("Retrying connection to Neo4j after %v...\n", retryDelay)
-		}
-	}
-}
-
-// Clean up data from previous run
-func cleanupPreviousRunData(ctx context.Context, session neo4j.SessionWithContext, rootName string) error {
-	_, err := session.Run(ctx, "MATCH (n) WHERE n.project = $project DETACH DELETE n", map[string]interface{}{"project": rootName})
-	if err != nil {
-		return fmt.Errorf("failed to clean up previous data: %v", err)
-	}
-	fmt.Println("Cleaned up data from previous run")
-	return nil
-}
-
-// Create uniqueness constraints in Neo4j
-func createUniquenessConstraints(ctx context.Context, session neo4j.SessionWithContext) error {
-	constraints := []string{
-		"CREATE CONSTRAINT IF NOT EXISTS FOR (p:Package) REQUIRE p.id IS UNIQUE",
-		"CREATE CONSTRAINT IF NOT EXISTS FOR (s:Struct) REQUIRE s.id IS UNIQUE",
-		"CREATE CONSTRAINT IF NOT EXISTS FOR (f:Function) REQUIRE f.id IS UNIQUE",
-		"CREATE CONSTRAINT IF NOT EXISTS FOR (m:Method) REQUIRE m.id IS UNIQUE",
-	}
-	for _, constraint := range constraints {
-		_, err := session.Run(ctx, constraint, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create uniqueness constraint: %v", err)
-		}
-	}
-	return nil
-}
-
 // Find Go files in the project concurrently
 func findGoFiles(root string) ([]string, error) {
 	var wg sync.WaitGroup
@@ -137,6 +61,10 @@ func createUrl(baseUrl, filePath, projectRoot string, lineNumber int) string {
 }
 
 func main() {
+	full := flag.Bool("full", false, "wipe all existing data for this project and reindex everything, instead of the default incremental update")
+	rollback := flag.Bool("rollback", false, "revert the most recently applied schema migration and exit, instead of indexing")
+	flag.Parse()
+
 	rootName := os.Getenv("ROOT_NAME")
 	if rootName == "" {
 		log.Fatal("ROOT_NAME environment variable is not set")
@@ -147,38 +75,30 @@ func main() {
 	}
 	projectRoot := "/app"
 
-	// Connect to Neo4j
 	ctx := context.Background()
-	driver, err := createNeo4jDriver()
+	store, err := graphstore.NewFromEnv(ctx)
 	if err != nil {
-		log.Fatalf("Failed to connect to Neo4j: %v", err)
+		log.Fatalf("Failed to connect to graph store: %v", err)
 	}
-	defer driver.Close(ctx)
+	defer store.Close(ctx)
 
-	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
-	defer session.Close(ctx)
-
-	// Clean up previous data and create constraints
-	err = cleanupPreviousRunData(ctx, session, rootName)
-	if err != nil {
-		log.Fatalf("Cleanup error: %v", err)
-	}
-
-	err = createUniquenessConstraints(ctx, session)
-	if err != nil {
-		log.Fatalf("Constraint creation error: %v", err)
+	if *rollback {
+		rb, ok := store.(interface {
+			RollbackMigration(ctx context.Context) error
+		})
+		if !ok {
+			log.Fatal("--rollback is only supported for the neo4j backend")
+		}
+		if err := rb.RollbackMigration(ctx); err != nil {
+			log.Fatalf("Failed to rollback migration: %v", err)
+		}
+		return
 	}
 
-	// Create root node
-	_, err = session.Run(ctx,
-		"MERGE (r:Root {name: $rootName, project: $project, color: $color})",
-		map[string]interface{}{
-			"rootName": rootName,
-			"project":  rootName,
-			"color":    "orange",
-		})
-	if err != nil {
-		log.Fatalf("Failed to create root node: %v", err)
+	if *full {
+		if err := store.Cleanup(ctx, rootName); err != nil {
+			log.Fatalf("Cleanup error: %v", err)
+		}
 	}
 
 	// Find Go files
@@ -194,75 +114,243 @@ func main() {
 		wg.Add(1)
 		go func(filePath string) {
 			defer wg.Done()
-			processGoFile(ctx, filePath, fset, projectRoot, baseUrl, session, rootName)
+			processGoFile(ctx, filePath, fset, projectRoot, baseUrl, store, rootName, *full)
 		}(filePath)
 	}
 	wg.Wait()
+
+	// Tear down File nodes (and everything they contain) for files that no
+	// longer exist on disk. processGoFile only reconciles symbols within a
+	// file that's still there; a file deleted from the repo entirely would
+	// otherwise keep its nodes and edges forever. Skipped after --full,
+	// since Cleanup already wiped the project clean.
+	if !*full {
+		if err := reconcileDeletedFiles(ctx, store, rootName, goFiles, projectRoot); err != nil {
+			log.Fatalf("Failed to reconcile deleted files: %v", err)
+		}
+	}
+
+	if err := analyzeCallGraph(ctx, store, projectRoot, rootName); err != nil {
+		log.Fatalf("Call graph analysis error: %v", err)
+	}
+}
+
+// reconcileDeletedFiles diffs the File nodes recorded for rootName against
+// currentFiles (the current findGoFiles walk), and deletes the File node
+// and every symbol it contains for anything missing from the walk.
+func reconcileDeletedFiles(ctx context.Context, store graphstore.GraphStore, rootName string, currentFiles []string, projectRoot string) error {
+	recordedFileIDs, err := store.FilesInProject(ctx, rootName)
+	if err != nil {
+		return fmt.Errorf("failed to list recorded files: %w", err)
+	}
+
+	currentSet := make(map[string]struct{}, len(currentFiles))
+	for _, filePath := range currentFiles {
+		relativePath := strings.TrimPrefix(filePath, projectRoot+"/")
+		currentSet[fmt.Sprintf("%s:%s", rootName, relativePath)] = struct{}{}
+	}
+
+	for _, fileID := range recordedFileIDs {
+		if _, ok := currentSet[fileID]; ok {
+			continue
+		}
+		symbols, err := store.SymbolsInFile(ctx, fileID)
+		if err != nil {
+			log.Printf("Failed to read symbols for deleted file %s: %v", fileID, err)
+			continue
+		}
+		removeStaleSymbols(ctx, store, append(symbols, fileID), nil)
+	}
+	return nil
 }
 
-// Process Go file and extract AST information
-func processGoFile(ctx context.Context, filePath string, fset *token.FileSet, projectRoot, baseUrl string, session neo4j.SessionWithContext, rootName string) {
+// Process Go file and extract AST information. Unless force is set, a file
+// whose content hash matches the last recorded run is skipped entirely;
+// otherwise its symbols are re-emitted and any symbol that disappeared
+// since the last run is deleted.
+func processGoFile(ctx context.Context, filePath string, fset *token.FileSet, projectRoot, baseUrl string, store graphstore.GraphStore, rootName string, force bool) {
 	relativePath := strings.TrimPrefix(filePath, projectRoot+"/")
 	packageName := filepath.Dir(relativePath)
 	packageId := fmt.Sprintf("%s:%s", rootName, packageName)
+	fileId := fmt.Sprintf("%s:%s", rootName, relativePath)
+
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Printf("Failed to read %s: %v", filePath, err)
+		return
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256(src))
+
+	if !force {
+		previousHash, ok, err := store.FileHash(ctx, fileId)
+		if err != nil {
+			log.Printf("Failed to read previous hash for %s, reparsing: %v", filePath, err)
+		} else if ok && previousHash == hash {
+			return
+		}
+	}
+
+	previousSymbols, err := store.SymbolsInFile(ctx, fileId)
+	if err != nil {
+		log.Printf("Failed to read previous symbols for %s: %v", filePath, err)
+	}
 
 	// Parse the Go file
-	node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+	node, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
 	if err != nil {
 		log.Printf("Failed to parse Go file %s: %v", filePath, err)
 		return
 	}
 
 	// Create or merge Package node
-	_, err = session.Run(ctx,
-		"MERGE (p:Package {id: $id}) "+
-			"ON CREATE SET p.name = $name, p.project = $project, p.color = $color, p.url = $url",
-		map[string]interface{}{
-			"id":      packageId,
-			"name":    packageName,
-			"project": rootName,
-			"color":   NodeColors["Package"],
-			"url":     createUrl(baseUrl, filePath, projectRoot, 1),
-		})
+	err = store.UpsertPackage(ctx, graphstore.Package{
+		ID:      packageId,
+		Name:    packageName,
+		Project: rootName,
+		Color:   graphstore.NodeColors["Package"],
+		URL:     createUrl(baseUrl, filePath, projectRoot, 1),
+	})
 	if err != nil {
 		log.Printf("Failed to create package node: %v", err)
 		return
 	}
 
-	// Traverse the AST
-	ast.Inspect(node, func(n ast.Node) bool {
-		switch x := n.(type) {
+	if err := store.UpsertFile(ctx, graphstore.File{ID: fileId, Path: relativePath, Project: rootName, Hash: hash}); err != nil {
+		log.Printf("Failed to create file node: %v", err)
+		return
+	}
+	if err := store.LinkContains(ctx, packageId, fileId); err != nil {
+		log.Printf("Failed to link package to file: %v", err)
+	}
+
+	var currentSymbols []string
+	record := func(symbolId string) {
+		currentSymbols = append(currentSymbols, symbolId)
+		if err := store.LinkContains(ctx, fileId, symbolId); err != nil {
+			log.Printf("Failed to link file to symbol: %v", err)
+		}
+	}
+
+	// Walk the file's top-level declarations only, so a local `var err
+	// error` inside a function body doesn't get mistaken for a package-level
+	// variable the way a full ast.Inspect would.
+	for _, decl := range node.Decls {
+		switch x := decl.(type) {
 		case *ast.GenDecl:
-			processGenDecl(ctx, x, packageId, filePath, fset, rootName, session)
+			processGenDecl(ctx, x, packageId, filePath, fset, rootName, store, record)
 		case *ast.FuncDecl:
-			processFuncDecl(ctx, x, packageId, filePath, fset, rootName, session)
+			processFuncDecl(ctx, x, packageId, filePath, fset, rootName, store, record)
 		}
-		return true
-	})
+	}
+
+	removeStaleSymbols(ctx, store, previousSymbols, currentSymbols)
+}
+
+// removeStaleSymbols deletes every symbol in previous that isn't in current,
+// i.e. every symbol the last run saw in this file that disappeared.
+func removeStaleSymbols(ctx context.Context, store graphstore.GraphStore, previous, current []string) {
+	currentSet := make(map[string]struct{}, len(current))
+	for _, id := range current {
+		currentSet[id] = struct{}{}
+	}
+	for _, id := range previous {
+		if _, ok := currentSet[id]; ok {
+			continue
+		}
+		if err := store.DeleteSymbol(ctx, id); err != nil {
+			log.Printf("Failed to delete stale symbol %s: %v", id, err)
+		}
+	}
 }
 
-// Process generic declarations like Structs, Interfaces
-func processGenDecl(ctx context.Context, x *ast.GenDecl, packageId, filePath string, fset *token.FileSet, rootName string, session neo4j.SessionWithContext) {
+// Process generic declarations like Structs, Interfaces, and top-level
+// var/const declarations.
+func processGenDecl(ctx context.Context, x *ast.GenDecl, packageId, filePath string, fset *token.FileSet, rootName string, store graphstore.GraphStore, record func(string)) {
 	for _, spec := range x.Specs {
-		if typeSpec, ok := spec.(*ast.TypeSpec); ok {
-			typeName := typeSpec.Name.Name
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			typeName := s.Name.Name
 			typeId := fmt.Sprintf("%s.%s", packageId, typeName)
 
-			switch typeSpec.Type.(type) {
+			switch s.Type.(type) {
 			case *ast.StructType:
-					_, err := session.Run(ctx,
-					"MERGE (s:Struct {id: $id}) "+
-						"ON CREATE SET s.name = $name, s.packageId = $packageId, s.project = $project, s.color = $color, s.url = $url",
-					map[string]interface{}{
-						"id":        typeId,
-						"name":      typeName,
-						"packageId": packageId,
-						"project":   rootName,
-						"color":     NodeColors["Struct"],
-						"url":       createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
-					})
+				err := store.UpsertStruct(ctx, graphstore.Struct{
+					ID:        typeId,
+					Name:      typeName,
+					PackageID: packageId,
+					Project:   rootName,
+					Color:     graphstore.NodeColors["Struct"],
+					URL:       createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
+				})
 				if err != nil {
 					log.Printf("Failed to create struct node: %v", err)
+					continue
+				}
+				if err := store.LinkContains(ctx, packageId, typeId); err != nil {
+					log.Printf("Failed to link package to struct: %v", err)
+				}
+				record(typeId)
+			case *ast.InterfaceType:
+				err := store.UpsertInterface(ctx, graphstore.Interface{
+					ID:        typeId,
+					Name:      typeName,
+					PackageID: packageId,
+					Project:   rootName,
+					Color:     graphstore.NodeColors["Interface"],
+					URL:       createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
+				})
+				if err != nil {
+					log.Printf("Failed to create interface node: %v", err)
+					continue
+				}
+				if err := store.LinkContains(ctx, packageId, typeId); err != nil {
+					log.Printf("Failed to link package to interface: %v", err)
+				}
+				record(typeId)
+			}
+		case *ast.ValueSpec:
+			for _, name := range s.Names {
+				if name.Name == "_" {
+					continue
+				}
+				valueId := fmt.Sprintf("%s.%s", packageId, name.Name)
+				url := createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line)
+
+				switch x.Tok {
+				case token.VAR:
+					err := store.UpsertVariable(ctx, graphstore.Variable{
+						NodeID:    valueId,
+						Name:      name.Name,
+						PackageID: packageId,
+						Project:   rootName,
+						Color:     graphstore.NodeColors["Variable"],
+						URL:       url,
+					})
+					if err != nil {
+						log.Printf("Failed to create variable node: %v", err)
+						continue
+					}
+					if err := store.LinkContains(ctx, packageId, valueId); err != nil {
+						log.Printf("Failed to link package to variable: %v", err)
+					}
+					record(valueId)
+				case token.CONST:
+					err := store.UpsertConst(ctx, graphstore.Const{
+						NodeID:    valueId,
+						Name:      name.Name,
+						PackageID: packageId,
+						Project:   rootName,
+						Color:     graphstore.NodeColors["Const"],
+						URL:       url,
+					})
+					if err != nil {
+						log.Printf("Failed to create const node: %v", err)
+						continue
+					}
+					if err := store.LinkContains(ctx, packageId, valueId); err != nil {
+						log.Printf("Failed to link package to const: %v", err)
+					}
+					record(valueId)
 				}
 			}
 		}
@@ -270,7 +358,7 @@ func processGenDecl(ctx context.Context, x *ast.GenDecl, packageId, filePath str
 }
 
 // Process function and method declarations
-func processFuncDecl(ctx context.Context, x *ast.FuncDecl, packageId, filePath string, fset *token.FileSet, rootName string, session neo4j.SessionWithContext) {
+func processFuncDecl(ctx context.Context, x *ast.FuncDecl, packageId, filePath string, fset *token.FileSet, rootName string, store graphstore.GraphStore, record func(string)) {
 	funcName := x.Name.Name
 	funcSignature := funcName // Extend with parameters if needed
 	var funcId string
@@ -281,40 +369,42 @@ func processFuncDecl(ctx context.Context, x *ast.FuncDecl, packageId, filePath s
 			structId := fmt.Sprintf("%s.%s", packageId, recvType)
 			funcId = fmt.Sprintf("%s.%s", structId, funcSignature)
 
-			// Create or merge Method node
-			_, err := session.Run(ctx,
-				"MERGE (m:Method {id: $id}) "+
-					"ON CREATE SET m.name = $name, m.structId = $structId, m.project = $project, m.color = $color, m.url = $url",
-				map[string]interface{}{
-					"id":       funcId,
-					"name":     funcName,
-					"structId": structId,
-					"project":  rootName,
-					"color":    NodeColors["Method"],
-					"url":      createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
-				})
+			err := store.UpsertMethod(ctx, graphstore.Method{
+				ID:       funcId,
+				Name:     funcName,
+				StructID: structId,
+				Project:  rootName,
+				Color:    graphstore.NodeColors["Method"],
+				URL:      createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
+			})
 			if err != nil {
 				log.Printf("Failed to create method node: %v", err)
+				return
+			}
+			if err := store.LinkContains(ctx, structId, funcId); err != nil {
+				log.Printf("Failed to link struct to method: %v", err)
 			}
+			record(funcId)
 		}
 	} else {
 		funcId = fmt.Sprintf("%s.%s", packageId, funcSignature)
 
-		// Create or merge Function node
-		_, err := session.Run(ctx,
-			"MERGE (f:Function {id: $id}) "+
-				"ON CREATE SET f.name = $name, f.packageId = $packageId, f.project = $project, f.color = $color, f.url = $url",
-			map[string]interface{}{
-				"id":        funcId,
-				"name":      funcName,
-				"packageId": packageId,
-				"project":   rootName,
-				"color":     NodeColors["Function"],
-				"url":       createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
-			})
+		err := store.UpsertFunction(ctx, graphstore.Function{
+			ID:        funcId,
+			Name:      funcName,
+			PackageID: packageId,
+			Project:   rootName,
+			Color:     graphstore.NodeColors["Function"],
+			URL:       createUrl("/app", filePath, "/app", fset.Position(x.Pos()).Line),
+		})
 		if err != nil {
 			log.Printf("Failed to create function node: %v", err)
+			return
+		}
+		if err := store.LinkContains(ctx, packageId, funcId); err != nil {
+			log.Printf("Failed to link package to function: %v", err)
 		}
+		record(funcId)
 	}
 }
 
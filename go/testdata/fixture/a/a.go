@@ -0,0 +1,13 @@
+package a
+
+import "github.com/theapemachine/platform-graph/fixture/b"
+
+// Foo is the fixture's only struct; the integration test asserts it gets a
+// Struct node with a CONTAINS edge to its Bar method.
+type Foo struct{}
+
+// Bar calls into package b, so the integration test can assert a
+// cross-package CALLS edge from a.Foo.Bar to b.Baz.
+func (f *Foo) Bar() {
+	b.Baz()
+}
@@ -0,0 +1,5 @@
+package b
+
+// Baz is called from package a, so the integration test can assert a
+// cross-package CALLS edge.
+func Baz() {}
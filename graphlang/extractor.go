@@ -0,0 +1,29 @@
+package graphlang
+
+import "path/filepath"
+
+// Extractor turns the contents of a source file into a normalized Symbol
+// stream. Each Extractor is registered for the file extensions it handles.
+type Extractor interface {
+	// Extensions lists the file extensions (including the leading dot) this
+	// extractor handles, e.g. []string{".py"}.
+	Extensions() []string
+
+	// Extract parses src (the contents of the file at path) and returns the
+	// symbols it finds.
+	Extract(path string, src []byte) ([]Symbol, error)
+}
+
+var extractors = map[string]Extractor{}
+
+// RegisterExtractor makes an Extractor available for each of its
+// extensions. It is meant to be called from package init functions.
+func RegisterExtractor(e Extractor) {
+	for _, ext := range e.Extensions() {
+		extractors[ext] = e
+	}
+}
+
+func extractorFor(path string) Extractor {
+	return extractors[filepath.Ext(path)]
+}
@@ -0,0 +1,88 @@
+package graphlang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+func init() { RegisterExtractor(goExtractor{}) }
+
+// goExtractor extracts symbols from Go source using the standard library's
+// go/parser, rather than tree-sitter — it predates the multi-language
+// dispatch and remains the most precise option for this one language.
+type goExtractor struct{}
+
+func (goExtractor) Extensions() []string { return []string{".go"} }
+
+func (goExtractor) Extract(path string, src []byte) ([]Symbol, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var symbols []Symbol
+	for _, imp := range node.Imports {
+		symbols = append(symbols, Symbol{Kind: SymbolImport, Name: strings.Trim(imp.Path.Value, `"`)})
+	}
+
+	// Only the file's top-level declarations are symbols in their own
+	// right; walking into function bodies would turn a local `var err
+	// error` into a package-level Variable symbol.
+	for _, decl := range node.Decls {
+		switch x := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range x.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					switch s.Type.(type) {
+					case *ast.StructType:
+						symbols = append(symbols, Symbol{Kind: SymbolStruct, Name: s.Name.Name, Line: fset.Position(x.Pos()).Line})
+					case *ast.InterfaceType:
+						symbols = append(symbols, Symbol{Kind: SymbolInterface, Name: s.Name.Name, Line: fset.Position(x.Pos()).Line})
+					}
+				case *ast.ValueSpec:
+					kind := SymbolVariable
+					if x.Tok == token.CONST {
+						kind = SymbolConst
+					}
+					for _, name := range s.Names {
+						if name.Name == "_" {
+							continue
+						}
+						symbols = append(symbols, Symbol{Kind: kind, Name: name.Name, Line: fset.Position(x.Pos()).Line})
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if x.Recv != nil && len(x.Recv.List) > 0 {
+				if recvType := extractReceiverType(x.Recv); recvType != "" {
+					symbols = append(symbols, Symbol{Kind: SymbolMethod, Name: x.Name.Name, ParentID: recvType, Line: fset.Position(x.Pos()).Line})
+				}
+			} else {
+				symbols = append(symbols, Symbol{Kind: SymbolFunction, Name: x.Name.Name, Line: fset.Position(x.Pos()).Line})
+			}
+		}
+	}
+
+	return symbols, nil
+}
+
+// extractReceiverType extracts the receiver type name from a method declaration.
+func extractReceiverType(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+	switch expr := recv.List[0].Type.(type) {
+	case *ast.StarExpr:
+		if ident, ok := expr.X.(*ast.Ident); ok {
+			return ident.Name
+		}
+	case *ast.Ident:
+		return expr.Name
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+package graphlang
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/java"
+)
+
+func init() {
+	RegisterExtractor(&treeSitterExtractor{
+		languages: map[string]*sitter.Language{
+			".java": java.GetLanguage(),
+		},
+		nodeKinds: map[string]SymbolKind{
+			"class_declaration":     SymbolStruct,
+			"interface_declaration": SymbolInterface,
+			"method_declaration":    SymbolMethod,
+		},
+		importType: "import_declaration",
+	})
+}
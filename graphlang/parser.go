@@ -0,0 +1,216 @@
+// Package graphlang analyzes a directory tree and emits the symbols it
+// finds into a graphstore.GraphStore. Each file is handled by the Extractor
+// registered for its extension, so adding support for a new language is a
+// matter of registering an Extractor rather than changing the walk itself.
+package graphlang
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/theapemachine/platform-graph/graphstore"
+)
+
+// TreeSitterParser walks a directory, dispatches each file to the Extractor
+// registered for its extension, and writes the resulting symbols to a
+// GraphStore.
+type TreeSitterParser struct {
+	store    graphstore.GraphStore
+	rootName string
+	baseURL  string
+	dirPath  string
+	workers  int
+}
+
+// NewTreeSitterParser returns a TreeSitterParser that writes to store.
+func NewTreeSitterParser(store graphstore.GraphStore, rootName, baseURL, dirPath string) *TreeSitterParser {
+	return &TreeSitterParser{
+		store:    store,
+		rootName: rootName,
+		baseURL:  baseURL,
+		dirPath:  dirPath,
+		workers:  runtime.NumCPU(),
+	}
+}
+
+// AnalyzeDirectory walks dirPath once and dispatches every file with a
+// registered Extractor to a worker pool for analysis.
+func (p *TreeSitterParser) AnalyzeDirectory(dirPath string) error {
+	paths := make(chan string)
+	var wg sync.WaitGroup
+
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				p.analyzeFile(context.Background(), path)
+			}
+		}()
+	}
+
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		if extractorFor(path) == nil {
+			return nil
+		}
+		paths <- path
+		return nil
+	})
+	close(paths)
+	wg.Wait()
+
+	return err
+}
+
+func (p *TreeSitterParser) analyzeFile(ctx context.Context, path string) {
+	extractor := extractorFor(path)
+	if extractor == nil {
+		return
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Failed to read %s: %v", path, err)
+		return
+	}
+
+	symbols, err := extractor.Extract(path, src)
+	if err != nil {
+		log.Printf("Failed to extract symbols from %s: %v", path, err)
+		return
+	}
+
+	p.persist(ctx, path, symbols)
+}
+
+// persist writes a file's symbol stream to the GraphStore, scoping each
+// symbol's ID to the file's package/module and linking it to its parent.
+func (p *TreeSitterParser) persist(ctx context.Context, filePath string, symbols []Symbol) {
+	relativePath := strings.TrimPrefix(filePath, p.dirPath+"/")
+	packageName := filepath.Dir(relativePath)
+	packageID := fmt.Sprintf("%s:%s", p.rootName, packageName)
+
+	err := p.store.UpsertPackage(ctx, graphstore.Package{
+		ID:      packageID,
+		Name:    packageName,
+		Project: p.rootName,
+		Color:   graphstore.NodeColors["Package"],
+		URL:     p.url(filePath, 1),
+	})
+	if err != nil {
+		log.Printf("Failed to create package node: %v", err)
+		return
+	}
+
+	// Structs and interfaces are written first so methods below can resolve
+	// the struct/interface ID their ParentID (a bare name) refers to.
+	parentIDs := map[string]string{}
+	for _, sym := range symbols {
+		switch sym.Kind {
+		case SymbolStruct:
+			id := fmt.Sprintf("%s.%s", packageID, sym.Name)
+			parentIDs[sym.Name] = id
+			if err := p.store.UpsertStruct(ctx, graphstore.Struct{
+				ID: id, Name: sym.Name, PackageID: packageID, Project: p.rootName,
+				Color: graphstore.NodeColors["Struct"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create struct node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, packageID, id); err != nil {
+				log.Printf("Failed to link package to struct: %v", err)
+			}
+		case SymbolInterface:
+			id := fmt.Sprintf("%s.%s", packageID, sym.Name)
+			parentIDs[sym.Name] = id
+			if err := p.store.UpsertInterface(ctx, graphstore.Interface{
+				ID: id, Name: sym.Name, PackageID: packageID, Project: p.rootName,
+				Color: graphstore.NodeColors["Interface"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create interface node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, packageID, id); err != nil {
+				log.Printf("Failed to link package to interface: %v", err)
+			}
+		}
+	}
+
+	for _, sym := range symbols {
+		switch sym.Kind {
+		case SymbolFunction:
+			id := fmt.Sprintf("%s.%s", packageID, sym.Name)
+			if err := p.store.UpsertFunction(ctx, graphstore.Function{
+				ID: id, Name: sym.Name, PackageID: packageID, Project: p.rootName,
+				Color: graphstore.NodeColors["Function"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create function node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, packageID, id); err != nil {
+				log.Printf("Failed to link package to function: %v", err)
+			}
+		case SymbolMethod:
+			structID, ok := parentIDs[sym.ParentID]
+			if !ok {
+				structID = fmt.Sprintf("%s.%s", packageID, sym.ParentID)
+			}
+			id := fmt.Sprintf("%s.%s", structID, sym.Name)
+			if err := p.store.UpsertMethod(ctx, graphstore.Method{
+				ID: id, Name: sym.Name, StructID: structID, Project: p.rootName,
+				Color: graphstore.NodeColors["Method"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create method node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, structID, id); err != nil {
+				log.Printf("Failed to link struct to method: %v", err)
+			}
+		case SymbolVariable:
+			id := fmt.Sprintf("%s.%s", packageID, sym.Name)
+			if err := p.store.UpsertVariable(ctx, graphstore.Variable{
+				NodeID: id, Name: sym.Name, PackageID: packageID, Project: p.rootName,
+				Color: graphstore.NodeColors["Variable"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create variable node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, packageID, id); err != nil {
+				log.Printf("Failed to link package to variable: %v", err)
+			}
+		case SymbolConst:
+			id := fmt.Sprintf("%s.%s", packageID, sym.Name)
+			if err := p.store.UpsertConst(ctx, graphstore.Const{
+				NodeID: id, Name: sym.Name, PackageID: packageID, Project: p.rootName,
+				Color: graphstore.NodeColors["Const"], URL: p.url(filePath, sym.Line),
+			}); err != nil {
+				log.Printf("Failed to create const node: %v", err)
+				continue
+			}
+			if err := p.store.LinkContains(ctx, packageID, id); err != nil {
+				log.Printf("Failed to link package to const: %v", err)
+			}
+		case SymbolImport:
+			// Not persisted: an import symbol holds the raw import text,
+			// which this parser does not resolve to a Package ID, so no
+			// IMPORTS edge is written for it.
+		}
+	}
+}
+
+func (p *TreeSitterParser) url(filePath string, line int) string {
+	relativePath := strings.TrimPrefix(filePath, p.dirPath)
+	return fmt.Sprintf("%s%s#%d", p.baseURL, relativePath, line)
+}
@@ -0,0 +1,19 @@
+package graphlang
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+func init() {
+	RegisterExtractor(&treeSitterExtractor{
+		languages: map[string]*sitter.Language{
+			".py": python.GetLanguage(),
+		},
+		nodeKinds: map[string]SymbolKind{
+			"class_definition":    SymbolStruct,
+			"function_definition": SymbolFunction,
+		},
+		importType: "import_statement",
+	})
+}
@@ -0,0 +1,19 @@
+package graphlang
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/ruby"
+)
+
+func init() {
+	RegisterExtractor(&treeSitterExtractor{
+		languages: map[string]*sitter.Language{
+			".rb": ruby.GetLanguage(),
+		},
+		nodeKinds: map[string]SymbolKind{
+			"class":  SymbolStruct,
+			"module": SymbolInterface,
+			"method": SymbolFunction,
+		},
+	})
+}
@@ -0,0 +1,26 @@
+package graphlang
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+func init() {
+	RegisterExtractor(&treeSitterExtractor{
+		languages: map[string]*sitter.Language{
+			".rs": rust.GetLanguage(),
+		},
+		nodeKinds: map[string]SymbolKind{
+			"struct_item":   SymbolStruct,
+			"trait_item":    SymbolInterface,
+			"function_item": SymbolFunction,
+		},
+		// impl_item ("impl Foo { ... }") is a sibling of struct_item in
+		// Rust's grammar, not a child of it, so its methods need their own
+		// rule to be attributed to the type they're implemented on.
+		implKinds: map[string]string{
+			"impl_item": "type",
+		},
+		importType: "use_declaration",
+	})
+}
@@ -0,0 +1,29 @@
+package graphlang
+
+// SymbolKind identifies the category of a Symbol emitted by an Extractor.
+type SymbolKind string
+
+const (
+	SymbolStruct    SymbolKind = "Struct"
+	SymbolInterface SymbolKind = "Interface"
+	SymbolFunction  SymbolKind = "Function"
+	SymbolMethod    SymbolKind = "Method"
+	SymbolImport    SymbolKind = "Import"
+	SymbolVariable  SymbolKind = "Variable"
+	SymbolConst     SymbolKind = "Const"
+)
+
+// Symbol is the language-agnostic unit emitted by an Extractor. Extractors
+// normalize whatever their source language calls a class, struct, module,
+// etc. into one of these kinds so the rest of the pipeline never needs to
+// know which language a file came from.
+type Symbol struct {
+	Kind SymbolKind
+	Name string
+	// ParentID is the name of the struct/class/interface a symbol is
+	// declared inside: a Method's owner, or the enclosing type of a nested
+	// declaration. It is empty for top-level declarations and imports;
+	// package scoping is derived from the file's path instead.
+	ParentID string
+	Line     int
+}
@@ -0,0 +1,143 @@
+package graphlang
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// treeSitterExtractor walks a tree-sitter parse tree and maps node types to
+// Symbol kinds using nodeKinds. One instance can cover several extensions
+// that share a node vocabulary but parse with different grammars (e.g. .ts
+// and .tsx), by keying languages per extension.
+type treeSitterExtractor struct {
+	languages  map[string]*sitter.Language
+	nodeKinds  map[string]SymbolKind
+	importType string
+
+	// implKinds maps a node type that scopes its children under a named type
+	// without itself being the struct/interface node (e.g. Rust's
+	// impl_item, a sibling of struct_item rather than a wrapper around it)
+	// to the field name holding that type's name. No symbol is emitted for
+	// the node itself; its children are walked with that name as the
+	// current class context, the same as descending into a struct/interface.
+	implKinds map[string]string
+}
+
+func (e *treeSitterExtractor) Extensions() []string {
+	exts := make([]string, 0, len(e.languages))
+	for ext := range e.languages {
+		exts = append(exts, ext)
+	}
+	return exts
+}
+
+func (e *treeSitterExtractor) Extract(path string, src []byte) ([]Symbol, error) {
+	language, ok := e.languages[filepath.Ext(path)]
+	if !ok {
+		return nil, fmt.Errorf("no tree-sitter grammar registered for %s", path)
+	}
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(language)
+
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	defer tree.Close()
+
+	var symbols []Symbol
+	var className string
+
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		if n == nil {
+			return
+		}
+
+		// Anonymous (unnamed) nodes are the grammar's keyword/punctuation
+		// tokens, not rule matches — tree-sitter-ruby, for example, gives
+		// the "class"/"module" keyword token the same type string as the
+		// class/module rule itself, so without this
+		// check every Ruby class/module body also "declares" a bogus
+		// nested symbol literally named "class"/"module".
+		if !n.IsNamed() {
+			for i := 0; i < int(n.ChildCount()); i++ {
+				walk(n.Child(i))
+			}
+			return
+		}
+
+		switch {
+		case n.Type() == e.importType:
+			symbols = append(symbols, Symbol{Kind: SymbolImport, Name: n.Content(src)})
+		case e.implKinds[n.Type()] != "":
+			if name := fieldContent(n, e.implKinds[n.Type()], src); name != "" {
+				previousClass := className
+				className = name
+				for i := 0; i < int(n.ChildCount()); i++ {
+					walk(n.Child(i))
+				}
+				className = previousClass
+				return
+			}
+		case e.nodeKinds[n.Type()] != "":
+			kind := e.nodeKinds[n.Type()]
+			if kind == SymbolFunction && className != "" {
+				// A function nested directly inside a class body is a method,
+				// even in languages (Python, Ruby, Rust) whose grammar uses
+				// one node type for both.
+				kind = SymbolMethod
+			}
+			name := symbolName(n, src)
+			symbols = append(symbols, Symbol{
+				Kind:     kind,
+				Name:     name,
+				ParentID: className,
+				Line:     int(n.StartPoint().Row) + 1,
+			})
+			if kind == SymbolStruct || kind == SymbolInterface {
+				previousClass := className
+				className = name
+				for i := 0; i < int(n.ChildCount()); i++ {
+					walk(n.Child(i))
+				}
+				className = previousClass
+			}
+			// Unlike structs/interfaces, a function/method's body isn't
+			// walked: the same principle go_extractor.go applies by only
+			// walking node.Decls, a closure or nested def in the body isn't
+			// a symbol of its own. Without this, a function nested inside
+			// another (common in Python/Ruby closures) gets emitted as if
+			// it were top-level.
+			return
+		}
+
+		for i := 0; i < int(n.ChildCount()); i++ {
+			walk(n.Child(i))
+		}
+	}
+	walk(tree.RootNode())
+
+	return symbols, nil
+}
+
+func symbolName(n *sitter.Node, src []byte) string {
+	if field := n.ChildByFieldName("name"); field != nil {
+		return field.Content(src)
+	}
+	return n.Content(src)
+}
+
+// fieldContent returns the text of n's field named fieldName, or "" if n has
+// no such field.
+func fieldContent(n *sitter.Node, fieldName string, src []byte) string {
+	field := n.ChildByFieldName(fieldName)
+	if field == nil {
+		return ""
+	}
+	return field.Content(src)
+}
@@ -0,0 +1,208 @@
+package graphlang
+
+import "testing"
+
+func TestTreeSitterExtractors(t *testing.T) {
+	tests := []struct {
+		name string
+		ext  string
+		src  string
+		want []Symbol
+	}{
+		{
+			name: "python class and method",
+			ext:  ".py",
+			src: `import os
+
+class Greeter:
+    def greet(self):
+        pass
+`,
+			want: []Symbol{
+				{Kind: SymbolImport, Name: "import os"},
+				{Kind: SymbolStruct, Name: "Greeter", Line: 3},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greeter", Line: 4},
+			},
+		},
+		{
+			name: "ruby class, module, and method",
+			ext:  ".rb",
+			src: `module Greetable
+  def greet
+  end
+end
+
+class Greeter
+  def greet
+  end
+end
+`,
+			// Ruby's grammar gives the "module"/"class" anonymous keyword
+			// tokens the same node type as the module/class rule itself;
+			// IsNamed() filters those out, so no bogus "module"/"class"
+			// symbol should be emitted alongside the real declarations.
+			want: []Symbol{
+				{Kind: SymbolInterface, Name: "Greetable", Line: 1},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greetable", Line: 2},
+				{Kind: SymbolStruct, Name: "Greeter", Line: 6},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greeter", Line: 7},
+			},
+		},
+		{
+			name: "rust struct, trait, and impl methods",
+			ext:  ".rs",
+			src: `use std::fmt;
+
+trait Greet {
+    fn greet(&self);
+}
+
+struct Greeter;
+
+impl Greeter {
+    fn greet(&self) {}
+}
+`,
+			// The trait's method has no body ("fn greet(&self);"), which
+			// Rust's grammar parses as a function_signature_item rather
+			// than the function_item nodeKinds maps, so it isn't emitted.
+			want: []Symbol{
+				{Kind: SymbolImport, Name: "use std::fmt;"},
+				{Kind: SymbolInterface, Name: "Greet", Line: 3},
+				{Kind: SymbolStruct, Name: "Greeter", Line: 7},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greeter", Line: 10},
+			},
+		},
+		{
+			name: "java class, interface, and method",
+			ext:  ".java",
+			src: `import java.util.List;
+
+interface Greet {
+    void greet();
+}
+
+class Greeter implements Greet {
+    void greet() {}
+}
+`,
+			want: []Symbol{
+				{Kind: SymbolImport, Name: "import java.util.List;"},
+				{Kind: SymbolInterface, Name: "Greet", Line: 3},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greet", Line: 4},
+				{Kind: SymbolStruct, Name: "Greeter", Line: 7},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greeter", Line: 8},
+			},
+		},
+		{
+			name: "typescript class, interface, function, and method",
+			ext:  ".ts",
+			src: `import { Foo } from "./foo";
+
+interface Greet {
+    greet(): void;
+}
+
+function standalone() {}
+
+class Greeter implements Greet {
+    greet() {}
+}
+`,
+			want: []Symbol{
+				{Kind: SymbolImport, Name: `import { Foo } from "./foo";`},
+				{Kind: SymbolInterface, Name: "Greet", Line: 3},
+				{Kind: SymbolFunction, Name: "standalone", Line: 7},
+				{Kind: SymbolStruct, Name: "Greeter", Line: 9},
+				{Kind: SymbolMethod, Name: "greet", ParentID: "Greeter", Line: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor := extractorFor("file" + tt.ext)
+			if extractor == nil {
+				t.Fatalf("no extractor registered for %s", tt.ext)
+			}
+
+			got, err := extractor.Extract("file"+tt.ext, []byte(tt.src))
+			if err != nil {
+				t.Fatalf("Extract: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Extract returned %d symbols, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("symbol %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestTreeSitterExtractor_nestedFunctionNotEmitted guards against a
+// function nested inside another function's body (a closure, common in
+// Python/Ruby) being mistaken for a top-level symbol, the same class of
+// bug go_extractor.go avoids by only walking node.Decls.
+func TestTreeSitterExtractor_nestedFunctionNotEmitted(t *testing.T) {
+	src := `def outer():
+    def inner():
+        pass
+    return inner
+`
+	extractor := extractorFor("file.py")
+	got, err := extractor.Extract("file.py", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := []Symbol{{Kind: SymbolFunction, Name: "outer", Line: 1}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("Extract = %+v, want %+v (inner should not be emitted)", got, want)
+	}
+}
+
+func TestGoExtractor(t *testing.T) {
+	src := `package demo
+
+import "fmt"
+
+type Greeter struct{}
+
+func (g Greeter) Greet() {
+	fmt.Println("hi")
+}
+
+func standalone() {
+	var local int
+	_ = local
+}
+
+var Exported int
+const MaxRetries = 3
+`
+	extractor := extractorFor("file.go")
+	got, err := extractor.Extract("file.go", []byte(src))
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+
+	want := []Symbol{
+		{Kind: SymbolImport, Name: "fmt"},
+		{Kind: SymbolStruct, Name: "Greeter", Line: 5},
+		{Kind: SymbolMethod, Name: "Greet", ParentID: "Greeter", Line: 7},
+		{Kind: SymbolFunction, Name: "standalone", Line: 11},
+		{Kind: SymbolVariable, Name: "Exported", Line: 16},
+		{Kind: SymbolConst, Name: "MaxRetries", Line: 17},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Extract returned %d symbols, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("symbol %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package graphlang
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+func init() {
+	RegisterExtractor(&treeSitterExtractor{
+		languages: map[string]*sitter.Language{
+			".ts":  typescript.GetLanguage(),
+			".tsx": tsx.GetLanguage(),
+		},
+		nodeKinds: map[string]SymbolKind{
+			"class_declaration":     SymbolStruct,
+			"interface_declaration": SymbolInterface,
+			"function_declaration":  SymbolFunction,
+			"method_definition":     SymbolMethod,
+		},
+		importType: "import_statement",
+	})
+}
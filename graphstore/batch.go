@@ -0,0 +1,215 @@
+package graphstore
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// DefaultBatchSize is the number of buffered rows that triggers an
+// immediate flush.
+const DefaultBatchSize = 1000
+
+// DefaultBatchInterval is how often buffered rows are flushed even if
+// DefaultBatchSize hasn't been reached yet.
+const DefaultBatchInterval = time.Second
+
+// nodeLabels and edgeTypes are flushed in this fixed order on every flush,
+// so a relationship is never written before the nodes it connects: both
+// ends of an edge are always enqueued before the edge itself, and flushing
+// every node buffer first guarantees they've landed by the time the edge
+// buffers are flushed. flushMu serializes concurrent flush calls (the
+// periodic ticker racing a batchSize trigger) from the buffer swap through
+// the writes, so this ordering holds across flushes too, not just within a
+// single one.
+var nodeLabels = []string{"Package", "File", "Struct", "Interface", "Function", "Method"}
+var edgeTypes = []string{"CONTAINS", "IMPORTS", "IMPLEMENTS", "CALLS"}
+
+type nodeRow struct {
+	id    string
+	props map[string]any
+}
+
+type edgeRow struct {
+	fromID string
+	toID   string
+}
+
+// batchWriter buffers node upserts and relationship writes and flushes them
+// in bulk with UNWIND, instead of running one Cypher statement per row.
+type batchWriter struct {
+	driver neo4j.DriverWithContext
+
+	batchSize int
+
+	mu      sync.Mutex
+	nodes   map[string][]nodeRow
+	edges   map[string][]edgeRow
+	pending int
+
+	// flushMu serializes flush itself, held from before the buffer swap
+	// until the writes finish, so buffers are written in the order they were
+	// swapped out. mu only guards the buffers, so without this a concurrent
+	// ticker-driven flush
+	// and a batchSize-triggered flush could both be writing at once, with
+	// no guarantee the one holding a node commits before the one holding
+	// an edge referencing it. flushEdges' MATCH silently matches zero rows
+	// when an endpoint is missing, so that race drops edges with no error.
+	flushMu sync.Mutex
+
+	ticker *time.Ticker
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newBatchWriter(driver neo4j.DriverWithContext, batchSize int, interval time.Duration) *batchWriter {
+	w := &batchWriter{
+		driver:    driver,
+		batchSize: batchSize,
+		nodes:     make(map[string][]nodeRow),
+		edges:     make(map[string][]edgeRow),
+		ticker:    time.NewTicker(interval),
+		done:      make(chan struct{}),
+	}
+
+	w.wg.Add(1)
+	go w.run()
+
+	return w
+}
+
+func (w *batchWriter) run() {
+	defer w.wg.Done()
+	for {
+		select {
+		case <-w.ticker.C:
+			if err := w.flush(context.Background()); err != nil {
+				log.Printf("Failed to flush batch: %v", err)
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *batchWriter) enqueueNode(ctx context.Context, label, id string, props map[string]any) error {
+	w.mu.Lock()
+	w.nodes[label] = append(w.nodes[label], nodeRow{id: id, props: props})
+	w.pending++
+	shouldFlush := w.pending >= w.batchSize
+	w.mu.Unlock()
+
+	if shouldFlush {
+		return w.flush(ctx)
+	}
+	return nil
+}
+
+func (w *batchWriter) enqueueEdge(ctx context.Context, relType, fromID, toID string) error {
+	w.mu.Lock()
+	w.edges[relType] = append(w.edges[relType], edgeRow{fromID: fromID, toID: toID})
+	w.pending++
+	shouldFlush := w.pending >= w.batchSize
+	w.mu.Unlock()
+
+	if shouldFlush {
+		return w.flush(ctx)
+	}
+	return nil
+}
+
+// flush drains every buffer and writes it in one transaction per label/type,
+// nodes before edges.
+func (w *batchWriter) flush(ctx context.Context) error {
+	w.flushMu.Lock()
+	defer w.flushMu.Unlock()
+
+	w.mu.Lock()
+	nodes := w.nodes
+	edges := w.edges
+	w.nodes = make(map[string][]nodeRow)
+	w.edges = make(map[string][]edgeRow)
+	w.pending = 0
+	w.mu.Unlock()
+
+	session := w.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	for _, label := range nodeLabels {
+		rows := nodes[label]
+		if len(rows) == 0 {
+			continue
+		}
+		if err := flushNodes(ctx, session, label, rows); err != nil {
+			return err
+		}
+	}
+
+	for _, relType := range edgeTypes {
+		rows := edges[relType]
+		if len(rows) == 0 {
+			continue
+		}
+		if err := flushEdges(ctx, session, relType, rows); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func flushNodes(ctx context.Context, session neo4j.SessionWithContext, label string, rows []nodeRow) error {
+	payload := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		payload[i] = map[string]any{"id": row.id, "props": row.props}
+	}
+
+	setClause := "ON CREATE SET n += row.props"
+	if label == "File" {
+		// A File's hash has to follow the file's content from run to run.
+		setClause = "SET n += row.props"
+	}
+	query := fmt.Sprintf(
+		"UNWIND $rows AS row MERGE (n:%s {id: row.id}) %s",
+		label, setClause)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return tx.Run(ctx, query, map[string]any{"rows": payload})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to flush %d %s row(s): %w", len(rows), label, err)
+	}
+	return nil
+}
+
+func flushEdges(ctx context.Context, session neo4j.SessionWithContext, relType string, rows []edgeRow) error {
+	payload := make([]map[string]any, len(rows))
+	for i, row := range rows {
+		payload[i] = map[string]any{"from": row.fromID, "to": row.toID}
+	}
+
+	query := fmt.Sprintf(
+		"UNWIND $rows AS row MATCH (from {id: row.from}), (to {id: row.to}) "+
+			"MERGE (from)-[:%s]->(to)",
+		relType)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return tx.Run(ctx, query, map[string]any{"rows": payload})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to flush %d %s edge(s): %w", len(rows), relType, err)
+	}
+	return nil
+}
+
+// close stops the periodic flush and drains whatever is still buffered.
+func (w *batchWriter) close(ctx context.Context) error {
+	close(w.done)
+	w.ticker.Stop()
+	w.wg.Wait()
+	return w.flush(ctx)
+}
@@ -0,0 +1,13 @@
+package graphstore
+
+// NodeColors maps node labels to the color used when rendering the graph.
+var NodeColors = map[string]string{
+	"Package":         "#4287f5",
+	"Function":        "#42f54e",
+	"Method":          "#42f54e",
+	"Struct":          "#f54242",
+	"Interface":       "#f5a442",
+	"Variable":        "#9b59b6",
+	"Const":           "#16a085",
+	"ExternalService": "#f5f542",
+}
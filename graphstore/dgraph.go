@@ -0,0 +1,367 @@
+package graphstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/dgraph-io/dgo/v210"
+	"github.com/dgraph-io/dgo/v210/protos/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// DgraphStore is a GraphStore backed by Dgraph, reached over gRPC.
+type DgraphStore struct {
+	conn   *grpc.ClientConn
+	client *dgo.Dgraph
+}
+
+// NewDgraphStore dials addr, sets up the predicate schema, and returns a
+// ready-to-use DgraphStore.
+func NewDgraphStore(ctx context.Context, addr string) (*DgraphStore, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial Dgraph at %s: %w", addr, err)
+	}
+
+	client := dgo.NewDgraphClient(api.NewDgraphClient(conn))
+	store := &DgraphStore{conn: conn, client: client}
+	if err := store.ensureSchema(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *DgraphStore) ensureSchema(ctx context.Context) error {
+	schema := `
+		xid: string @index(exact) .
+		type: string @index(exact) .
+		name: string @index(term) .
+		packageId: string @index(exact) .
+		structId: string @index(exact) .
+		project: string @index(exact) .
+		color: string .
+		url: string .
+		path: string .
+		hash: string .
+		contains: [uid] .
+		calls: [uid] @reverse .
+		imports: [uid] .
+		implements: [uid] .
+	`
+	return s.client.Alter(ctx, &api.Operation{Schema: schema})
+}
+
+// quoteLiteral renders s as an N-Quads string literal, the mutation-side
+// equivalent of a Cypher parameter: dgo has no placeholder syntax for
+// values inside SetNquads/DelNquads, so every literal written into a
+// mutation goes through this single escaping path instead of an ad hoc
+// fmt.Sprintf("%q", ...) at each call site.
+func quoteLiteral(s string) string {
+	return strconv.Quote(s)
+}
+
+// byXid is the DQL query-variable block every lookup-by-id query shares:
+// the id is bound through $id rather than spliced into the query text, the
+// same role neo4j.go's $id Cypher parameters play.
+const byXid = `query q($id: string) { node as var(func: eq(xid, $id)) }`
+
+// upsert runs a query-then-mutate upsert block keyed on xid, setting props
+// on the matched (or newly created) node and tagging it with nodeType.
+func (s *DgraphStore) upsert(ctx context.Context, id, nodeType string, props map[string]string) error {
+	nquads := fmt.Sprintf("uid(node) <xid> %s .\nuid(node) <type> %s .\n", quoteLiteral(id), quoteLiteral(nodeType))
+	for predicate, value := range props {
+		nquads += fmt.Sprintf("uid(node) <%s> %s .\n", predicate, quoteLiteral(value))
+	}
+
+	mutation := &api.Mutation{SetNquads: []byte(nquads)}
+	req := &api.Request{
+		Query:     byXid,
+		Vars:      map[string]string{"$id": id},
+		Mutations: []*api.Mutation{mutation},
+		CommitNow: true,
+	}
+
+	if _, err := s.client.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("failed to upsert %s node %s: %w", nodeType, id, err)
+	}
+	return nil
+}
+
+func (s *DgraphStore) UpsertPackage(ctx context.Context, pkg Package) error {
+	return s.upsert(ctx, pkg.ID, "Package", map[string]string{
+		"name":    pkg.Name,
+		"project": pkg.Project,
+		"color":   pkg.Color,
+		"url":     pkg.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertStruct(ctx context.Context, st Struct) error {
+	return s.upsert(ctx, st.ID, "Struct", map[string]string{
+		"name":      st.Name,
+		"packageId": st.PackageID,
+		"project":   st.Project,
+		"color":     st.Color,
+		"url":       st.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertFunction(ctx context.Context, fn Function) error {
+	return s.upsert(ctx, fn.ID, "Function", map[string]string{
+		"name":      fn.Name,
+		"packageId": fn.PackageID,
+		"project":   fn.Project,
+		"color":     fn.Color,
+		"url":       fn.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertMethod(ctx context.Context, m Method) error {
+	return s.upsert(ctx, m.ID, "Method", map[string]string{
+		"name":     m.Name,
+		"structId": m.StructID,
+		"project":  m.Project,
+		"color":    m.Color,
+		"url":      m.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertInterface(ctx context.Context, i Interface) error {
+	return s.upsert(ctx, i.ID, "Interface", map[string]string{
+		"name":      i.Name,
+		"packageId": i.PackageID,
+		"project":   i.Project,
+		"color":     i.Color,
+		"url":       i.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertVariable(ctx context.Context, v Variable) error {
+	return s.upsert(ctx, v.ID(), "Variable", map[string]string{
+		"name":      v.Name,
+		"packageId": v.PackageID,
+		"project":   v.Project,
+		"color":     v.Color,
+		"url":       v.URL,
+	})
+}
+
+func (s *DgraphStore) UpsertConst(ctx context.Context, c Const) error {
+	return s.upsert(ctx, c.ID(), "Const", map[string]string{
+		"name":      c.Name,
+		"packageId": c.PackageID,
+		"project":   c.Project,
+		"color":     c.Color,
+		"url":       c.URL,
+	})
+}
+
+const byFromTo = `query q($from: string, $to: string) {
+	from as var(func: eq(xid, $from))
+	to as var(func: eq(xid, $to))
+}`
+
+func (s *DgraphStore) LinkContains(ctx context.Context, parentID, childID string) error {
+	nquads := "uid(from) <contains> uid(to) ."
+	mutation := &api.Mutation{SetNquads: []byte(nquads)}
+	req := &api.Request{
+		Query:     byFromTo,
+		Vars:      map[string]string{"$from": parentID, "$to": childID},
+		Mutations: []*api.Mutation{mutation},
+		CommitNow: true,
+	}
+
+	if _, err := s.client.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("failed to link %s to %s: %w", parentID, childID, err)
+	}
+	return nil
+}
+
+func (s *DgraphStore) UpsertFile(ctx context.Context, f File) error {
+	return s.upsert(ctx, f.ID, "File", map[string]string{
+		"path":    f.Path,
+		"project": f.Project,
+		"hash":    f.Hash,
+	})
+}
+
+func (s *DgraphStore) FileHash(ctx context.Context, fileID string) (string, bool, error) {
+	query := `query q($id: string) { file(func: eq(xid, $id)) { hash } }`
+	resp, err := s.client.NewReadOnlyTxn().QueryWithVars(ctx, query, map[string]string{"$id": fileID})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query file hash: %w", err)
+	}
+
+	var decoded struct {
+		File []struct {
+			Hash string `json:"hash"`
+		} `json:"file"`
+	}
+	if err := json.Unmarshal(resp.Json, &decoded); err != nil {
+		return "", false, fmt.Errorf("failed to decode query response: %w", err)
+	}
+	if len(decoded.File) == 0 {
+		return "", false, nil
+	}
+	return decoded.File[0].Hash, true, nil
+}
+
+func (s *DgraphStore) SymbolsInFile(ctx context.Context, fileID string) ([]string, error) {
+	query := `query q($id: string) {
+		files(func: eq(xid, $id)) {
+			contains {
+				xid
+			}
+		}
+	}`
+	return s.queryEdgeIDs(ctx, query, map[string]string{"$id": fileID}, "files", "contains")
+}
+
+func (s *DgraphStore) DeleteSymbol(ctx context.Context, id string) error {
+	mutation := &api.Mutation{DelNquads: []byte("uid(node) * * .")}
+	req := &api.Request{
+		Query:     byXid,
+		Vars:      map[string]string{"$id": id},
+		Mutations: []*api.Mutation{mutation},
+		CommitNow: true,
+	}
+
+	if _, err := s.client.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("failed to delete symbol %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *DgraphStore) linkEdge(ctx context.Context, predicate, fromID, toID string) error {
+	nquads := fmt.Sprintf("uid(from) <%s> uid(to) .", predicate)
+	mutation := &api.Mutation{SetNquads: []byte(nquads)}
+	req := &api.Request{
+		Query:     byFromTo,
+		Vars:      map[string]string{"$from": fromID, "$to": toID},
+		Mutations: []*api.Mutation{mutation},
+		CommitNow: true,
+	}
+
+	if _, err := s.client.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("failed to link %s -[%s]-> %s: %w", fromID, predicate, toID, err)
+	}
+	return nil
+}
+
+func (s *DgraphStore) LinkCalls(ctx context.Context, callerID, calleeID string) error {
+	return s.linkEdge(ctx, "calls", callerID, calleeID)
+}
+
+func (s *DgraphStore) LinkImports(ctx context.Context, fromID, toID string) error {
+	return s.linkEdge(ctx, "imports", fromID, toID)
+}
+
+func (s *DgraphStore) LinkImplements(ctx context.Context, structID, interfaceID string) error {
+	return s.linkEdge(ctx, "implements", structID, interfaceID)
+}
+
+func (s *DgraphStore) CallersOf(ctx context.Context, id string) ([]string, error) {
+	query := `query q($id: string) {
+		callers(func: eq(xid, $id)) {
+			~calls {
+				xid
+			}
+		}
+	}`
+	return s.queryEdgeIDs(ctx, query, map[string]string{"$id": id}, "callers", "~calls")
+}
+
+func (s *DgraphStore) TransitiveCallees(ctx context.Context, id string) ([]string, error) {
+	query := `query q($id: string) {
+		callees(func: eq(xid, $id)) {
+			calls @recurse {
+				xid
+			}
+		}
+	}`
+	return s.queryEdgeIDs(ctx, query, map[string]string{"$id": id}, "callees", "calls")
+}
+
+// FilesInProject returns the IDs of every File node tagged with project.
+func (s *DgraphStore) FilesInProject(ctx context.Context, project string) ([]string, error) {
+	query := `query q($project: string) {
+		files(func: eq(type, "File")) @filter(eq(project, $project)) {
+			xid
+		}
+	}`
+	resp, err := s.client.NewReadOnlyTxn().QueryWithVars(ctx, query, map[string]string{"$project": project})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query files in project %s: %w", project, err)
+	}
+
+	var decoded struct {
+		Files []struct {
+			Xid string `json:"xid"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(resp.Json, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode query response: %w", err)
+	}
+
+	ids := make([]string, len(decoded.Files))
+	for i, f := range decoded.Files {
+		ids[i] = f.Xid
+	}
+	return ids, nil
+}
+
+// queryEdgeIDs runs query with vars and collects the xid of every node
+// reachable through the named edge off the root node.
+func (s *DgraphStore) queryEdgeIDs(ctx context.Context, query string, vars map[string]string, rootField, edge string) ([]string, error) {
+	resp, err := s.client.NewReadOnlyTxn().QueryWithVars(ctx, query, vars)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %w", err)
+	}
+
+	var decoded map[string][]map[string]any
+	if err := json.Unmarshal(resp.Json, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode query response: %w", err)
+	}
+
+	var ids []string
+	for _, root := range decoded[rootField] {
+		related, ok := root[edge].([]any)
+		if !ok {
+			continue
+		}
+		for _, r := range related {
+			node, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+			if xid, ok := node["xid"].(string); ok {
+				ids = append(ids, xid)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (s *DgraphStore) Cleanup(ctx context.Context, project string) error {
+	query := `query q($project: string) { nodes as var(func: eq(project, $project)) }`
+	mutation := &api.Mutation{DelNquads: []byte("uid(nodes) * * .")}
+	req := &api.Request{
+		Query:     query,
+		Vars:      map[string]string{"$project": project},
+		Mutations: []*api.Mutation{mutation},
+		CommitNow: true,
+	}
+
+	if _, err := s.client.NewTxn().Do(ctx, req); err != nil {
+		return fmt.Errorf("failed to clean up previous data: %w", err)
+	}
+	return nil
+}
+
+func (s *DgraphStore) Close(ctx context.Context) error {
+	return s.conn.Close()
+}
@@ -0,0 +1,37 @@
+package graphstore
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// NewFromEnv builds a GraphStore for the backend named by GRAPH_BACKEND
+// ("neo4j" or "dgraph"), reading that backend's connection details from the
+// environment. It defaults to "neo4j" when GRAPH_BACKEND is unset, keeping
+// existing deployments working unchanged.
+func NewFromEnv(ctx context.Context) (GraphStore, error) {
+	backend := os.Getenv("GRAPH_BACKEND")
+	if backend == "" {
+		backend = "neo4j"
+	}
+
+	switch backend {
+	case "neo4j":
+		uri := os.Getenv("NEO4J_URI")
+		user := os.Getenv("NEO4J_USER")
+		password := os.Getenv("NEO4J_PASSWORD")
+		if uri == "" || user == "" || password == "" {
+			return nil, fmt.Errorf("NEO4J_URI, NEO4J_USER and NEO4J_PASSWORD must be set")
+		}
+		return NewNeo4jStore(ctx, uri, user, password)
+	case "dgraph":
+		addr := os.Getenv("DGRAPH_ADDR")
+		if addr == "" {
+			return nil, fmt.Errorf("DGRAPH_ADDR must be set")
+		}
+		return NewDgraphStore(ctx, addr)
+	default:
+		return nil, fmt.Errorf("unknown GRAPH_BACKEND %q: must be \"neo4j\" or \"dgraph\"", backend)
+	}
+}
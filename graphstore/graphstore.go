@@ -0,0 +1,180 @@
+// Package graphstore abstracts the graph database backend used to persist
+// the symbols and relationships discovered while analyzing a codebase.
+// Callers depend only on the GraphStore interface so the underlying
+// database (Neo4j, Dgraph, ...) can be swapped without touching the
+// analysis pipeline.
+package graphstore
+
+import (
+	"context"
+
+	"github.com/theapemachine/platform-graph/orm"
+)
+
+// Package represents a source package or module node.
+type Package struct {
+	ID      string
+	Name    string
+	Project string
+	Color   string
+	URL     string
+}
+
+// Struct represents a struct (or equivalent record/class) node.
+type Struct struct {
+	ID        string
+	Name      string
+	PackageID string
+	Project   string
+	Color     string
+	URL       string
+}
+
+// Function represents a free function node.
+type Function struct {
+	ID        string
+	Name      string
+	PackageID string
+	Project   string
+	Color     string
+	URL       string
+}
+
+// Method represents a function with a receiver, attached to a Struct.
+type Method struct {
+	ID       string
+	Name     string
+	StructID string
+	Project  string
+	Color    string
+	URL      string
+}
+
+// Interface represents an interface (or equivalent protocol/trait) node.
+type Interface struct {
+	ID        string
+	Name      string
+	PackageID string
+	Project   string
+	Color     string
+	URL       string
+}
+
+// File represents a source file, tracked so the analyzer can skip
+// reparsing files whose content hasn't changed since the last run.
+type File struct {
+	ID      string
+	Path    string
+	Project string
+	Hash    string
+}
+
+// Variable represents a package-level variable declaration. Unlike the node
+// kinds above, Neo4jStore writes Variable through the orm package (DgraphStore
+// writes it with the same DQL upsert as every other kind): it's the
+// pattern a new node kind should follow from here on, rather than growing
+// the hand-written Cypher in Neo4jStore further. Its id lives in NodeID,
+// not ID, since orm.Node requires an ID() method and a field can't share a
+// method's name.
+//
+// TODO(chunk0-7): Package/Struct/Function/Method/Interface still stay on
+// the hand-written Cypher in Neo4jStore and batchWriter rather than moving
+// to orm too, since those go through batchWriter's UNWIND batching (see
+// batch.go) and orm.Repository writes one row at a time today — migrating
+// them as-is would reintroduce the per-row write cost batching was added
+// to fix. That's a reasonable argument for narrowing chunk0-7 to new node
+// kinds only, but it's my argument, not a sign-off from whoever filed the
+// request. Either get that confirmed, or teach orm.Repository to batch its
+// writes so the original request can be done in full.
+type Variable struct {
+	NodeID    string `neo4j:"-"`
+	Name      string `neo4j:"name"`
+	PackageID string `neo4j:"packageId"`
+	Project   string `neo4j:"project"`
+	Color     string `neo4j:"color"`
+	URL       string `neo4j:"url"`
+}
+
+func (v Variable) Labels() []string { return []string{"Variable"} }
+func (v Variable) ID() string       { return v.NodeID }
+
+// Const represents a package-level constant declaration.
+type Const struct {
+	NodeID    string `neo4j:"-"`
+	Name      string `neo4j:"name"`
+	PackageID string `neo4j:"packageId"`
+	Project   string `neo4j:"project"`
+	Color     string `neo4j:"color"`
+	URL       string `neo4j:"url"`
+}
+
+func (c Const) Labels() []string { return []string{"Const"} }
+func (c Const) ID() string       { return c.NodeID }
+
+var (
+	_ orm.Node = Variable{}
+	_ orm.Node = Const{}
+)
+
+// GraphStore persists the nodes and relationships emitted by an analyzer.
+// Implementations must be safe for concurrent use, since the analysis
+// pipeline processes files in parallel.
+type GraphStore interface {
+	UpsertPackage(ctx context.Context, pkg Package) error
+	UpsertStruct(ctx context.Context, s Struct) error
+	UpsertFunction(ctx context.Context, f Function) error
+	UpsertMethod(ctx context.Context, m Method) error
+	UpsertInterface(ctx context.Context, i Interface) error
+
+	// In Neo4jStore, UpsertVariable and UpsertConst are written through the
+	// orm package rather than hand-rolled Cypher; DgraphStore writes them
+	// with its usual DQL upsert. See Variable and Const.
+	UpsertVariable(ctx context.Context, v Variable) error
+	UpsertConst(ctx context.Context, c Const) error
+
+	// UpsertFile records (or updates) the content hash of a source file,
+	// used to decide whether the file needs reparsing on the next run.
+	UpsertFile(ctx context.Context, f File) error
+
+	// FileHash returns the hash recorded for fileID by the last run, and
+	// whether a record existed at all (a new file reports ok=false).
+	FileHash(ctx context.Context, fileID string) (hash string, ok bool, err error)
+
+	// SymbolsInFile returns the IDs of every symbol currently linked to
+	// fileID, so a reparse can tell which ones disappeared.
+	SymbolsInFile(ctx context.Context, fileID string) ([]string, error)
+
+	// FilesInProject returns the IDs of every File node recorded for
+	// project, so an incremental run can tell which ones no longer exist
+	// on disk and need to be torn down entirely.
+	FilesInProject(ctx context.Context, project string) ([]string, error)
+
+	// DeleteSymbol removes a single node (and its relationships), used to
+	// drop symbols that no longer exist after an incremental reparse.
+	DeleteSymbol(ctx context.Context, id string) error
+
+	// LinkContains records that parentID contains childID, e.g. a Package
+	// containing a Struct, or a Struct containing a Method.
+	LinkContains(ctx context.Context, parentID, childID string) error
+
+	// LinkCalls records that the function/method callerID calls calleeID.
+	LinkCalls(ctx context.Context, callerID, calleeID string) error
+
+	// LinkImports records that the package fromID imports the package toID.
+	LinkImports(ctx context.Context, fromID, toID string) error
+
+	// LinkImplements records that the struct structID implements interfaceID.
+	LinkImplements(ctx context.Context, structID, interfaceID string) error
+
+	// CallersOf returns the IDs of every function/method that directly calls id.
+	CallersOf(ctx context.Context, id string) ([]string, error)
+
+	// TransitiveCallees returns the IDs of every function/method reachable
+	// from id by following CALLS edges any number of hops.
+	TransitiveCallees(ctx context.Context, id string) ([]string, error)
+
+	// Cleanup removes all nodes and relationships belonging to project.
+	Cleanup(ctx context.Context, project string) error
+
+	Close(ctx context.Context) error
+}
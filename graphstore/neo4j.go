@@ -0,0 +1,268 @@
+package graphstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/theapemachine/platform-graph/migrate"
+	"github.com/theapemachine/platform-graph/orm"
+)
+
+// Neo4jStore is a GraphStore backed by a Neo4j database, reached over Bolt.
+// Node and relationship writes go through a batchWriter rather than
+// `session.Run` directly, so large repos don't pay one round trip per
+// struct/function/method. Every other query opens its own short-lived
+// session instead of sharing one across the store, since the driver's
+// Session (and Transaction) are not safe for concurrent use and GraphStore
+// implementations must be.
+type Neo4jStore struct {
+	driver neo4j.DriverWithContext
+	batch  *batchWriter
+}
+
+// NewNeo4jStore connects to Neo4j, verifies connectivity, and makes sure the
+// uniqueness constraints the rest of the store relies on are in place.
+func NewNeo4jStore(ctx context.Context, uri, user, password string) (*Neo4jStore, error) {
+	return NewNeo4jStoreWithBatch(ctx, uri, user, password, DefaultBatchSize, DefaultBatchInterval)
+}
+
+// NewNeo4jStoreWithBatch is NewNeo4jStore with an explicit batch size and
+// flush interval, mainly useful for tests that want small, fast batches.
+func NewNeo4jStoreWithBatch(ctx context.Context, uri, user, password string, batchSize int, batchInterval time.Duration) (*Neo4jStore, error) {
+	driver, err := neo4j.NewDriverWithContext(uri, neo4j.BasicAuth(user, password, ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
+	}
+	if err := driver.VerifyConnectivity(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to Neo4j: %w", err)
+	}
+	if err := migrate.Run(ctx, driver); err != nil {
+		driver.Close(ctx)
+		return nil, fmt.Errorf("failed to run schema migrations: %w", err)
+	}
+
+	store := &Neo4jStore{driver: driver}
+	store.batch = newBatchWriter(driver, batchSize, batchInterval)
+	return store, nil
+}
+
+// RollbackMigration reverts the most recently applied schema migration via
+// migrate.Rollback. It's deliberately not part of the GraphStore interface:
+// migrations are a Neo4j-specific concept (DgraphStore has no equivalent),
+// and every other GraphStore caller is backend-agnostic. go/main.go's
+// --rollback flag reaches this through a type assertion instead.
+func (s *Neo4jStore) RollbackMigration(ctx context.Context) error {
+	return migrate.Rollback(ctx, s.driver)
+}
+
+// session opens a fresh session for a single query. Callers must close it
+// when done; this is the same pattern batchWriter.flush uses for its bulk
+// writes.
+func (s *Neo4jStore) session(ctx context.Context) neo4j.SessionWithContext {
+	return s.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+}
+
+func (s *Neo4jStore) UpsertPackage(ctx context.Context, pkg Package) error {
+	return s.batch.enqueueNode(ctx, "Package", pkg.ID, map[string]any{
+		"name": pkg.Name, "project": pkg.Project, "color": pkg.Color, "url": pkg.URL,
+	})
+}
+
+func (s *Neo4jStore) UpsertStruct(ctx context.Context, st Struct) error {
+	return s.batch.enqueueNode(ctx, "Struct", st.ID, map[string]any{
+		"name": st.Name, "packageId": st.PackageID, "project": st.Project, "color": st.Color, "url": st.URL,
+	})
+}
+
+func (s *Neo4jStore) UpsertFunction(ctx context.Context, fn Function) error {
+	return s.batch.enqueueNode(ctx, "Function", fn.ID, map[string]any{
+		"name": fn.Name, "packageId": fn.PackageID, "project": fn.Project, "color": fn.Color, "url": fn.URL,
+	})
+}
+
+func (s *Neo4jStore) UpsertMethod(ctx context.Context, m Method) error {
+	return s.batch.enqueueNode(ctx, "Method", m.ID, map[string]any{
+		"name": m.Name, "structId": m.StructID, "project": m.Project, "color": m.Color, "url": m.URL,
+	})
+}
+
+func (s *Neo4jStore) UpsertInterface(ctx context.Context, i Interface) error {
+	return s.batch.enqueueNode(ctx, "Interface", i.ID, map[string]any{
+		"name": i.Name, "packageId": i.PackageID, "project": i.Project, "color": i.Color, "url": i.URL,
+	})
+}
+
+// UpsertVariable and UpsertConst go through orm.Repository instead of the
+// batch writer: they're new, low-volume node kinds, so there's no need to
+// hand-write their Cypher the way the node kinds above still do. Each call
+// gets its own Repository bound to a fresh session, since these run
+// concurrently across files the same way the hand-written Upsert* above do.
+func (s *Neo4jStore) UpsertVariable(ctx context.Context, v Variable) error {
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	return orm.NewRepository[Variable](session).Upsert(ctx, v)
+}
+
+func (s *Neo4jStore) UpsertConst(ctx context.Context, c Const) error {
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	return orm.NewRepository[Const](session).Upsert(ctx, c)
+}
+
+func (s *Neo4jStore) UpsertFile(ctx context.Context, f File) error {
+	return s.batch.enqueueNode(ctx, "File", f.ID, map[string]any{
+		"path": f.Path, "project": f.Project, "hash": f.Hash,
+	})
+}
+
+func (s *Neo4jStore) FileHash(ctx context.Context, fileID string) (string, bool, error) {
+	if err := s.batch.flush(ctx); err != nil {
+		return "", false, err
+	}
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	result, err := session.Run(ctx,
+		"MATCH (f:File {id: $id}) RETURN f.hash AS hash",
+		map[string]any{"id": fileID})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query file hash: %w", err)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		// Single reports a *neo4j.UsageError when the result has no
+		// records at all, i.e. fileID is new — that's the only case that
+		// should look like "no hash recorded yet". Anything else (a
+		// dropped connection, a query timeout, ...) is a real failure and
+		// must not be mistaken for a new file, or it forces a silent full
+		// reparse instead of surfacing why the hash check failed.
+		var usageErr *neo4j.UsageError
+		if errors.As(err, &usageErr) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read file hash result: %w", err)
+	}
+	hash, _ := record.Get("hash")
+	h, _ := hash.(string)
+	return h, true, nil
+}
+
+func (s *Neo4jStore) SymbolsInFile(ctx context.Context, fileID string) ([]string, error) {
+	if err := s.batch.flush(ctx); err != nil {
+		return nil, err
+	}
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	result, err := session.Run(ctx,
+		"MATCH (f:File {id: $id})-[:CONTAINS]->(sym) RETURN sym.id AS id",
+		map[string]any{"id": fileID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query symbols in file %s: %w", fileID, err)
+	}
+	return collectIDs(ctx, result)
+}
+
+func (s *Neo4jStore) FilesInProject(ctx context.Context, project string) ([]string, error) {
+	if err := s.batch.flush(ctx); err != nil {
+		return nil, err
+	}
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	result, err := session.Run(ctx,
+		"MATCH (f:File {project: $project}) RETURN f.id AS id",
+		map[string]any{"project": project})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query files in project %s: %w", project, err)
+	}
+	return collectIDs(ctx, result)
+}
+
+func (s *Neo4jStore) DeleteSymbol(ctx context.Context, id string) error {
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	_, err := session.Run(ctx,
+		"MATCH (n {id: $id}) DETACH DELETE n",
+		map[string]any{"id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete symbol %s: %w", id, err)
+	}
+	return nil
+}
+
+func (s *Neo4jStore) LinkContains(ctx context.Context, parentID, childID string) error {
+	return s.batch.enqueueEdge(ctx, "CONTAINS", parentID, childID)
+}
+
+func (s *Neo4jStore) LinkCalls(ctx context.Context, callerID, calleeID string) error {
+	return s.batch.enqueueEdge(ctx, "CALLS", callerID, calleeID)
+}
+
+func (s *Neo4jStore) LinkImports(ctx context.Context, fromID, toID string) error {
+	return s.batch.enqueueEdge(ctx, "IMPORTS", fromID, toID)
+}
+
+func (s *Neo4jStore) LinkImplements(ctx context.Context, structID, interfaceID string) error {
+	return s.batch.enqueueEdge(ctx, "IMPLEMENTS", structID, interfaceID)
+}
+
+func (s *Neo4jStore) CallersOf(ctx context.Context, id string) ([]string, error) {
+	if err := s.batch.flush(ctx); err != nil {
+		return nil, err
+	}
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	result, err := session.Run(ctx,
+		"MATCH (caller)-[:CALLS]->(callee {id: $id}) RETURN DISTINCT caller.id AS id",
+		map[string]any{"id": id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query callers of %s: %w", id, err)
+	}
+	return collectIDs(ctx, result)
+}
+
+func (s *Neo4jStore) TransitiveCallees(ctx context.Context, id string) ([]string, error) {
+	if err := s.batch.flush(ctx); err != nil {
+		return nil, err
+	}
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	result, err := session.Run(ctx,
+		"MATCH (f {id: $id})-[:CALLS*1..]->(callee) RETURN DISTINCT callee.id AS id",
+		map[string]any{"id": id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transitive callees of %s: %w", id, err)
+	}
+	return collectIDs(ctx, result)
+}
+
+func collectIDs(ctx context.Context, result neo4j.ResultWithContext) ([]string, error) {
+	var ids []string
+	for result.Next(ctx) {
+		id, _ := result.Record().Get("id")
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+	return ids, result.Err()
+}
+
+func (s *Neo4jStore) Cleanup(ctx context.Context, project string) error {
+	session := s.session(ctx)
+	defer session.Close(ctx)
+	_, err := session.Run(ctx,
+		"MATCH (n) WHERE n.project = $project DETACH DELETE n",
+		map[string]any{"project": project})
+	if err != nil {
+		return fmt.Errorf("failed to clean up previous data: %w", err)
+	}
+	return nil
+}
+
+func (s *Neo4jStore) Close(ctx context.Context) error {
+	if err := s.batch.close(ctx); err != nil {
+		return err
+	}
+	return s.driver.Close(ctx)
+}
@@ -5,20 +5,15 @@ import (
 	"log"
 	"os"
 
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/theapemachine/platform-graph/graphlang"
+	"github.com/theapemachine/platform-graph/graphstore"
 )
 
-// main initializes configuration from environment variables, connects to a Neo4j database, and analyzes a directory using a TreeSitter-based parser.
+// main initializes configuration from environment variables, connects to the
+// configured graph store, and analyzes a directory using a TreeSitter-based parser.
 func main() {
-	neo4jURI := os.Getenv("NEO4J_URI")
-	neo4jUser := os.Getenv("NEO4J_USER")
-	neo4jPassword := os.Getenv("NEO4J_PASSWORD")
 	rootName := os.Getenv("ROOT_NAME")
 	baseURL := os.Getenv("BASE_URL")
-	if neo4jURI == "" || neo4jUser == "" || neo4jPassword == "" {
-		log.Fatal("NEO4J_URI, NEO4J_USER and NEO4J_PASSWORD must be set")
-	}
 	if rootName == "" {
 		rootName = "UnknownRoot"
 	}
@@ -26,15 +21,15 @@ func main() {
 		baseURL = "http://localhost"
 	}
 
-	log.Printf("Connecting to Neo4j at %s with user %s and password %s\n", neo4jURI, neo4jUser, neo4jPassword)
-	driver, err := neo4j.NewDriverWithContext(neo4jURI, neo4j.BasicAuth(neo4jUser, neo4jPassword, ""))
+	ctx := context.Background()
+	store, err := graphstore.NewFromEnv(ctx)
 	if err != nil {
-		log.Fatalf("Failed to create Neo4j driver: %v", err)
+		log.Fatalf("Failed to connect to graph store: %v", err)
 	}
-	defer driver.Close(context.Background())
+	defer store.Close(ctx)
 
 	dirPath := "/app"
 
-	parser := graphlang.NewTreeSitterParser(driver, rootName, baseURL, dirPath)
+	parser := graphlang.NewTreeSitterParser(store, rootName, baseURL, dirPath)
 	parser.AnalyzeDirectory(dirPath)
 }
@@ -0,0 +1,197 @@
+// Package migrate applies versioned Cypher migrations to a Neo4j database,
+// tracking which ones have already run on a :SchemaMigration node so the
+// tool's schema (constraints, indexes) can evolve the way a SQL project
+// would evolve with migration files, instead of a hardcoded constraint list.
+package migrate
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+//go:embed migrations/*.up.cypher
+var upMigrations embed.FS
+
+//go:embed migrations/*.down.cypher
+var downMigrations embed.FS
+
+type migration struct {
+	version int
+	name    string
+	cypher  string
+}
+
+// Run applies every migration in migrations/ that hasn't already been
+// recorded, in version order. Each migration runs in its own transaction;
+// a failure rolls back that migration and Run returns without applying any
+// migration after it.
+func Run(ctx context.Context, driver neo4j.DriverWithContext) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	if _, err := session.Run(ctx,
+		"CREATE CONSTRAINT schema_migration_version_unique IF NOT EXISTS "+
+			"FOR (m:SchemaMigration) REQUIRE m.version IS UNIQUE", nil); err != nil {
+		return fmt.Errorf("failed to create schema_migration constraint: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		applied, err := isApplied(ctx, session, m.version)
+		if err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+
+		_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+			for _, stmt := range splitStatements(m.cypher) {
+				if _, err := tx.Run(ctx, stmt, nil); err != nil {
+					return nil, fmt.Errorf("migration %03d_%s failed: %w", m.version, m.name, err)
+				}
+			}
+			_, err := tx.Run(ctx,
+				"CREATE (m:SchemaMigration {version: $version, name: $name})",
+				map[string]any{"version": m.version, "name": m.name})
+			return nil, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the most recently applied migration by running its
+// .down.cypher script and removing its SchemaMigration record, the same
+// way `migrate down 1` would in a classical SQL migration tool. It is a
+// no-op if no migration has been applied yet.
+func Rollback(ctx context.Context, driver neo4j.DriverWithContext) error {
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer session.Close(ctx)
+
+	version, name, err := lastApplied(ctx, session)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+
+	contents, err := downMigrations.ReadFile(path.Join("migrations", downFilename(version, name)))
+	if err != nil {
+		return fmt.Errorf("failed to read down migration for %03d_%s: %w", version, name, err)
+	}
+
+	_, err = session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for _, stmt := range splitStatements(string(contents)) {
+			if _, err := tx.Run(ctx, stmt, nil); err != nil {
+				return nil, fmt.Errorf("rollback of migration %03d_%s failed: %w", version, name, err)
+			}
+		}
+		_, err := tx.Run(ctx,
+			"MATCH (m:SchemaMigration {version: $version}) DELETE m",
+			map[string]any{"version": version})
+		return nil, err
+	})
+	return err
+}
+
+// lastApplied returns the version and name of the most recently applied
+// migration, or version 0 if none has run yet.
+func lastApplied(ctx context.Context, session neo4j.SessionWithContext) (int, string, error) {
+	result, err := session.Run(ctx,
+		"MATCH (m:SchemaMigration) RETURN m.version AS version, m.name AS name ORDER BY m.version DESC LIMIT 1", nil)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query last applied migration: %w", err)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, "", nil
+	}
+	version, _ := record.Get("version")
+	name, _ := record.Get("name")
+	v, _ := version.(int64)
+	n, _ := name.(string)
+	return int(v), n, nil
+}
+
+func downFilename(version int, name string) string {
+	return fmt.Sprintf("%03d_%s.down.cypher", version, name)
+}
+
+func isApplied(ctx context.Context, session neo4j.SessionWithContext, version int) (bool, error) {
+	result, err := session.Run(ctx,
+		"MATCH (m:SchemaMigration {version: $version}) RETURN m.version AS version",
+		map[string]any{"version": version})
+	if err != nil {
+		return false, fmt.Errorf("failed to check schema_migration %d: %w", version, err)
+	}
+	_, err = result.Single(ctx)
+	return err == nil, nil
+}
+
+func loadMigrations() ([]migration, error) {
+	entries, err := upMigrations.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var migrations []migration
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".up.cypher") {
+			continue
+		}
+		version, name, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		contents, err := upMigrations.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, migration{version: version, name: name, cypher: string(contents)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+	return migrations, nil
+}
+
+// parseFilename extracts the version and name from "NNN_name.up.cypher".
+func parseFilename(filename string) (int, string, error) {
+	base := strings.TrimSuffix(filename, ".up.cypher")
+	parts := strings.SplitN(base, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("malformed migration filename %q: expected NNN_name.up.cypher", filename)
+	}
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed migration version in %q: %w", filename, err)
+	}
+	return version, parts[1], nil
+}
+
+// splitStatements splits a .cypher file's `;`-terminated statements, since
+// the driver only runs one statement per tx.Run call.
+func splitStatements(cypher string) []string {
+	var statements []string
+	for _, stmt := range strings.Split(cypher, ";") {
+		if trimmed := strings.TrimSpace(stmt); trimmed != "" {
+			statements = append(statements, trimmed)
+		}
+	}
+	return statements
+}
@@ -0,0 +1,87 @@
+package migrate
+
+import "testing"
+
+func TestParseFilename(t *testing.T) {
+	tests := []struct {
+		filename    string
+		wantVersion int
+		wantName    string
+		wantErr     bool
+	}{
+		{"001_initial_constraints.up.cypher", 1, "initial_constraints", false},
+		{"003_variable_const_constraints.up.cypher", 3, "variable_const_constraints", false},
+		{"no_version.up.cypher", 0, "", true},
+		{"abc_initial_constraints.up.cypher", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		version, name, err := parseFilename(tt.filename)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseFilename(%q): expected an error, got none", tt.filename)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseFilename(%q): unexpected error: %v", tt.filename, err)
+			continue
+		}
+		if version != tt.wantVersion || name != tt.wantName {
+			t.Errorf("parseFilename(%q) = (%d, %q), want (%d, %q)", tt.filename, version, name, tt.wantVersion, tt.wantName)
+		}
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	cypher := "CREATE CONSTRAINT a IF NOT EXISTS FOR (n:A) REQUIRE n.id IS UNIQUE;\n\nCREATE CONSTRAINT b IF NOT EXISTS FOR (n:B) REQUIRE n.id IS UNIQUE;\n"
+
+	got := splitStatements(cypher)
+	want := []string{
+		"CREATE CONSTRAINT a IF NOT EXISTS FOR (n:A) REQUIRE n.id IS UNIQUE",
+		"CREATE CONSTRAINT b IF NOT EXISTS FOR (n:B) REQUIRE n.id IS UNIQUE",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("splitStatements: got %d statements, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("splitStatements[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitStatements_emptyInput(t *testing.T) {
+	if got := splitStatements("  ;  ;\n"); len(got) != 0 {
+		t.Errorf("splitStatements on blank statements = %v, want empty", got)
+	}
+}
+
+func TestDownFilename(t *testing.T) {
+	if got, want := downFilename(1, "initial_constraints"), "001_initial_constraints.down.cypher"; got != want {
+		t.Errorf("downFilename(1, %q) = %q, want %q", "initial_constraints", got, want)
+	}
+}
+
+// TestLoadMigrations_matchesDownFiles makes sure every embedded
+// .up.cypher has a corresponding .down.cypher under the name downFilename
+// derives for it, so Rollback can always find the migration it needs.
+func TestLoadMigrations_matchesDownFiles(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("loadMigrations: expected at least one migration")
+	}
+
+	for i, m := range migrations {
+		if i > 0 && migrations[i-1].version >= m.version {
+			t.Errorf("loadMigrations: not sorted by version: %d before %d", migrations[i-1].version, m.version)
+		}
+		if _, err := downMigrations.ReadFile("migrations/" + downFilename(m.version, m.name)); err != nil {
+			t.Errorf("missing down migration for %03d_%s: %v", m.version, m.name, err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Package orm is a small reflection-based mapper between Go structs and
+// Neo4j nodes. A type becomes persistable by implementing Node and tagging
+// its fields, rather than a call site hand-writing a Cypher MERGE string
+// per type the way graphstore.Neo4jStore's Upsert* methods still do for
+// the original node kinds.
+package orm
+
+// Node is implemented by any type a Repository can persist. ID is the
+// MERGE key; Labels are the Cypher labels the node is created with.
+type Node interface {
+	Labels() []string
+	ID() string
+}
@@ -0,0 +1,67 @@
+package orm
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testNode struct {
+	NodeID string `neo4j:"-"`
+	Name   string `neo4j:"name"`
+	Color  string `neo4j:"color"`
+	Plain  string
+}
+
+func (n testNode) Labels() []string { return []string{"Test"} }
+func (n testNode) ID() string       { return n.NodeID }
+
+func TestPropsOf(t *testing.T) {
+	n := testNode{NodeID: "pkg.Foo", Name: "Foo", Color: "#fff", Plain: "bar"}
+
+	got := propsOf(n)
+	want := map[string]any{
+		"name":  "Foo",
+		"color": "#fff",
+		"plain": "bar",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("propsOf(%+v) = %v, want %v", n, got, want)
+	}
+}
+
+func TestLabelString(t *testing.T) {
+	if got, want := labelString(testNode{}), "Test"; got != want {
+		t.Errorf("labelString = %q, want %q", got, want)
+	}
+}
+
+func TestDecode_roundTripsPropsOf(t *testing.T) {
+	n := testNode{NodeID: "pkg.Foo", Name: "Foo", Color: "#fff", Plain: "bar"}
+
+	props := propsOf(n)
+	decoded, err := decode[testNode](n.ID(), props)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if decoded.NodeID != n.NodeID {
+		t.Errorf("decode: NodeID = %q, want %q", decoded.NodeID, n.NodeID)
+	}
+	if decoded.Name != n.Name || decoded.Color != n.Color || decoded.Plain != n.Plain {
+		t.Errorf("decode = %+v, want %+v", decoded, n)
+	}
+}
+
+func TestDecode_ignoresUnknownProps(t *testing.T) {
+	decoded, err := decode[testNode]("pkg.Foo", map[string]any{
+		"name":    "Foo",
+		"unknown": "whatever",
+	})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Name != "Foo" {
+		t.Errorf("decode: Name = %q, want %q", decoded.Name, "Foo")
+	}
+}
@@ -0,0 +1,121 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Repository is a generic, struct-tag-driven mapper between a Node type and
+// Neo4j. Adding a new node kind to the graph is just defining a struct that
+// implements Node, rather than hand-writing its Cypher.
+type Repository[T Node] struct {
+	session neo4j.SessionWithContext
+}
+
+// NewRepository returns a Repository that reads and writes through session.
+func NewRepository[T Node](session neo4j.SessionWithContext) *Repository[T] {
+	return &Repository[T]{session: session}
+}
+
+// Upsert creates n's node if it doesn't exist yet, setting every tagged
+// field as a property. Existing nodes are left with whatever values the
+// first run recorded, matching the ON CREATE SET convention the rest of
+// the store's MERGE statements already use.
+func (r *Repository[T]) Upsert(ctx context.Context, n T) error {
+	query := fmt.Sprintf(
+		"MERGE (node:%s {id: $id}) ON CREATE SET node += $props",
+		labelString(n))
+	_, err := r.session.Run(ctx, query, map[string]any{
+		"id":    n.ID(),
+		"props": propsOf(n),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert %s %s: %w", labelString(n), n.ID(), err)
+	}
+	return nil
+}
+
+// Link merges a rel relationship from the node with id fromID to the node
+// with id toID, setting props (if any) on the relationship itself.
+func (r *Repository[T]) Link(ctx context.Context, fromID, rel, toID string, props map[string]any) error {
+	query := fmt.Sprintf(
+		"MATCH (from {id: $fromId}), (to {id: $toId}) MERGE (from)-[edge:%s]->(to) SET edge += $props",
+		rel)
+	_, err := r.session.Run(ctx, query, map[string]any{
+		"fromId": fromID,
+		"toId":   toID,
+		"props":  props,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link %s -[%s]-> %s: %w", fromID, rel, toID, err)
+	}
+	return nil
+}
+
+// FindByID loads the node with id and decodes its properties back into a T.
+func (r *Repository[T]) FindByID(ctx context.Context, id string) (T, error) {
+	var zero T
+
+	result, err := r.session.Run(ctx, "MATCH (node {id: $id}) RETURN node", map[string]any{"id": id})
+	if err != nil {
+		return zero, fmt.Errorf("failed to query node %s: %w", id, err)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return zero, fmt.Errorf("node %s not found: %w", id, err)
+	}
+	raw, ok := record.Get("node")
+	if !ok {
+		return zero, fmt.Errorf("node %s not found", id)
+	}
+	props, ok := raw.(neo4j.Node)
+	if !ok {
+		return zero, fmt.Errorf("unexpected result shape for node %s", id)
+	}
+
+	return decode[T](id, props.Props)
+}
+
+// decode builds a T from a Neo4j node's decoded properties, matching each
+// field back up by its `neo4j` tag (or its name with the first letter
+// lowercased) the same way
+// propsOf derives the property name when writing.
+func decode[T Node](id string, raw map[string]any) (T, error) {
+	var out T
+
+	v := reflect.ValueOf(&out).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("neo4j")
+		if tag == "-" {
+			// The field the MERGE key (id) itself maps back onto, skipped
+			// by propsOf for the same reason: it isn't a settable property.
+			if fv := v.Field(i); fv.CanSet() && fv.Kind() == reflect.String {
+				fv.SetString(id)
+			}
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = strings.ToLower(field.Name[:1]) + field.Name[1:]
+		}
+
+		value, ok := raw[name]
+		if !ok {
+			continue
+		}
+		fv := v.Field(i)
+		if fv.CanSet() && reflect.TypeOf(value).AssignableTo(fv.Type()) {
+			fv.Set(reflect.ValueOf(value))
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,40 @@
+package orm
+
+import (
+	"reflect"
+	"strings"
+)
+
+// propsOf reflects over n's fields and returns the Cypher property map to
+// SET on its node. A field's `neo4j` tag names the property ("-" skips the
+// field); an untagged field falls back to its Go name with the first letter
+// lowercased.
+func propsOf(n Node) map[string]any {
+	v := reflect.ValueOf(n)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	t := v.Type()
+
+	props := make(map[string]any, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("neo4j")
+		if tag == "-" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = strings.ToLower(field.Name[:1]) + field.Name[1:]
+		}
+		props[name] = v.Field(i).Interface()
+	}
+	return props
+}
+
+// labelString joins a Node's labels for use in a Cypher node pattern, e.g.
+// "Struct" or "Struct:Exported".
+func labelString(n Node) string {
+	return strings.Join(n.Labels(), ":")
+}